@@ -0,0 +1,69 @@
+package splinter
+
+// anchorWeight is the sample weight given to anchor points, large enough for the fit to pass through them to within
+// the precision of practical data.
+const anchorWeight = 1e6
+
+// AnchorPoints adds samples the fit should pass through. splinter has no equality constraints, so this is an
+// approximation: the anchors are added to the builder's samples (replacing existing samples with the same input) and
+// given a weight of 1e6, against the previous weights or 1 for the other samples. Weights are only used with
+// SmoothingPspline; without smoothing every sample, anchors included, is interpolated anyway, while with
+// SmoothingIdentity anchors are ordinary samples.
+//
+// The weights replace any set before, so calling Weights afterwards discards the anchoring.
+func (builder *BSplineBuilder) AnchorPoints(x [][]float64, y []float64) error {
+	if len(x) != len(y) {
+		return ErrLengthMismatch
+	}
+	if len(y) == 0 {
+		return ErrNoSamples
+	}
+	for _, row := range x {
+		if len(row) != len(x[0]) || (len(builder.y) > 0 && len(row) != len(builder.x[0])) {
+			return ErrDimensionMismatch
+		}
+	}
+
+	// anchors first, splinter keeps the first of several samples with the same input
+	allX := append(append([][]float64{}, x...), builder.x...)
+	allY := append(append([]float64{}, y...), builder.y...)
+	dt, err := newDataTableFromSamples(allX, allY)
+	if err != nil {
+		return err
+	}
+	defer dt.Free()
+
+	// both sample sets are sorted, so the previous weights are found by walking the old samples alongside
+	weights := make([]float64, len(dt.y))
+	j := 0
+	for i, row := range dt.x {
+		anchored := false
+		for _, a := range x {
+			if equalX(a, row) {
+				anchored = true
+				break
+			}
+		}
+
+		for j < len(builder.x) && lessX(builder.x[j], row) {
+			j++
+		}
+
+		switch {
+		case anchored:
+			weights[i] = anchorWeight
+		case len(builder.config.Weights) == len(builder.y):
+			weights[i] = builder.config.Weights[j]
+		default:
+			weights[i] = 1
+		}
+	}
+
+	cfg := builder.config
+	cfg.Weights = weights
+	err = builder.replaceTable(dt)
+	if err != nil {
+		return err
+	}
+	return builder.Configure(cfg)
+}
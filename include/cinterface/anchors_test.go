@@ -0,0 +1,46 @@
+package splinter
+
+import (
+	"testing"
+)
+
+func TestAnchorPoints(t *testing.T) {
+	builder, err := NewBSplineBuilder(noisyTable(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.Configure(BuilderConfig{Smoothing: SmoothingPspline, Alpha: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	// pull the fit far away from the data at 1.5 and pin an existing sample to a new value
+	anchorX := [][]float64{{1.5}, {3}}
+	anchorY := []float64{3, -1}
+	if err := builder.AnchorPoints(anchorX, anchorY); err != nil {
+		t.Fatal(err)
+	}
+	if len(builder.y) != 41 {
+		t.Errorf("expected one new and one replaced sample, got %d samples", len(builder.y))
+	}
+
+	bs, err := builder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, x := range anchorX {
+		got, err := bs.Eval(x...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !almostEqual(got, anchorY[i], 1e-3) {
+			t.Errorf("at %v: expected %v, got %v", x, anchorY[i], got)
+		}
+	}
+
+	if err := builder.AnchorPoints(anchorX, anchorY[:1]); err != ErrLengthMismatch {
+		t.Errorf("expected ErrLengthMismatch, got %v", err)
+	}
+	if err := builder.AnchorPoints([][]float64{{1, 2}}, []float64{0}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
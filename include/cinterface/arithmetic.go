@@ -0,0 +1,197 @@
+package splinter
+
+import (
+	"fmt"
+	"math"
+)
+
+// CompatibleWith reports whether the spline has the same structure as other, that is the same degrees and exactly the
+// same knot vectors, so that the two can be combined coefficient-wise.
+func (bs *BSpline) CompatibleWith(other *BSpline) (bool, error) {
+	if other == nil {
+		return false, ErrInvalidNil
+	}
+
+	degrees, err := bs.Degrees()
+	if err != nil {
+		return false, err
+	}
+	otherDegrees, err := other.Degrees()
+	if err != nil {
+		return false, err
+	}
+
+	if len(degrees) != len(otherDegrees) {
+		return false, nil
+	}
+	for i := range degrees {
+		if degrees[i] != otherDegrees[i] {
+			return false, nil
+		}
+	}
+
+	knotVectors, err := bs.KnotVectors()
+	if err != nil {
+		return false, err
+	}
+	otherKnotVectors, err := other.KnotVectors()
+	if err != nil {
+		return false, err
+	}
+
+	for i := range knotVectors {
+		if len(knotVectors[i]) != len(otherKnotVectors[i]) {
+			return false, nil
+		}
+		for j := range knotVectors[i] {
+			if knotVectors[i][j] != otherKnotVectors[i][j] {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// Subtract returns a new spline whose coefficients are the differences of the coefficients of bs and other, which
+// represents bs - other exactly. Both splines must be structurally compatible (see CompatibleWith); ErrIncompatible
+// is returned otherwise. The result clamps negative values if bs does (see SetNonNegative), which is rarely wanted for
+// a difference; turn it off with SetNonNegative(false).
+func (bs *BSpline) Subtract(other *BSpline) (*BSpline, error) {
+	ok, err := bs.CompatibleWith(other)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrIncompatible
+	}
+
+	coeffs, err := bs.GetCoefficients()
+	if err != nil {
+		return nil, err
+	}
+	otherCoeffs, err := other.GetCoefficients()
+	if err != nil {
+		return nil, err
+	}
+	for i := range coeffs {
+		coeffs[i] -= otherCoeffs[i]
+	}
+	return bs.withCoefficients(coeffs)
+}
+
+// BlendEval evaluates the blend (1-w)*a + w*b of two splines at the given point, for w between 0 and 1. Unlike a
+// coefficient-wise combination this only requires both splines to have the same number of variables, not the same
+// knots, so it can cross-fade between unrelated fits.
+func BlendEval(a, b *BSpline, w float64, vals ...float64) (float64, error) {
+	if a == nil || b == nil {
+		return 0, ErrInvalidNil
+	}
+	if !(w >= 0 && w <= 1) {
+		return 0, ErrInvalidBlend
+	}
+
+	na, err := a.NumVariables()
+	if err != nil {
+		return 0, err
+	}
+	nb, err := b.NumVariables()
+	if err != nil {
+		return 0, err
+	}
+	if na != nb {
+		return 0, ErrDimensionMismatch
+	}
+
+	va, err := a.Eval(vals...)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := b.Eval(vals...)
+	if err != nil {
+		return 0, err
+	}
+	return (1-w)*va + w*vb, nil
+}
+
+// ErrEnsembleMember is returned by EvalEnsemble when the spline at Index in the ensemble cannot be evaluated at the
+// given point, with Err the reason.
+type ErrEnsembleMember struct {
+	Index int
+	Err   error
+}
+
+func (e ErrEnsembleMember) Error() string {
+	return fmt.Sprintf("Ensemble member %d: %v", e.Index, e.Err)
+}
+
+// EvalEnsemble evaluates every spline of an ensemble at the same point, returning the values in the order of splines.
+// All members are checked against the point before any is evaluated, so a mismatched ensemble fails fast with an
+// ErrEnsembleMember naming the first nil spline or spline whose number of variables differs from len(vals).
+func EvalEnsemble(splines []*BSpline, vals ...float64) ([]float64, error) {
+	for i, bs := range splines {
+		if bs == nil {
+			return nil, ErrEnsembleMember{Index: i, Err: ErrInvalidNil}
+		}
+		n, err := bs.NumVariables()
+		if err != nil {
+			return nil, ErrEnsembleMember{Index: i, Err: err}
+		}
+		if n != len(vals) {
+			return nil, ErrEnsembleMember{Index: i, Err: ErrDimensionMismatch}
+		}
+	}
+
+	values := make([]float64, len(splines))
+	for i, bs := range splines {
+		v, err := bs.Eval(vals...)
+		if err != nil {
+			return nil, ErrEnsembleMember{Index: i, Err: err}
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// withCoefficients returns a copy of the spline with the given coefficients.
+func (bs *BSpline) withCoefficients(coeffs []float64) (*BSpline, error) {
+	res, err := bs.clone()
+	if err != nil {
+		return nil, err
+	}
+
+	err = res.SetCoefficients(coeffs)
+	if err != nil {
+		res.Free()
+		return nil, err
+	}
+	return res, nil
+}
+
+// Prune returns a copy of the spline where the coefficients smaller than tol in magnitude are set to zero, together
+// with their indices. Removing them altogether would break the tensor product structure of the basis, so the number
+// of coefficients is unchanged; the zeros make the spline compress well for storage. The result differs from the
+// spline by at most the sum of the pruned magnitudes, since the basis functions are non-negative and sum to one.
+func (bs *BSpline) Prune(tol float64) (*BSpline, []int, error) {
+	if tol <= 0 {
+		return nil, nil, ErrInvalidTolerance
+	}
+
+	coeffs, err := bs.GetCoefficients()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pruned := []int{}
+	for i, c := range coeffs {
+		if math.Abs(c) < tol {
+			coeffs[i] = 0
+			pruned = append(pruned, i)
+		}
+	}
+
+	res, err := bs.withCoefficients(coeffs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, pruned, nil
+}
@@ -0,0 +1,163 @@
+package splinter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCompatibleWith(t *testing.T) {
+	a := newTestSpline1D(t)
+	b := buildTestSpline(t, [][]float64{linspace(0, 2, 21)}, func(x []float64) float64 { return 3 * x[0] })
+	c := buildTestSpline(t, [][]float64{linspace(0, 2, 11)}, square)
+
+	if ok, err := a.CompatibleWith(b); err != nil || !ok {
+		t.Errorf("expected splines on the same samples to be compatible, got %v, %v", ok, err)
+	}
+	if ok, err := a.CompatibleWith(c); err != nil || ok {
+		t.Errorf("expected splines with different knots to be incompatible, got %v, %v", ok, err)
+	}
+	if ok, err := a.CompatibleWith(newTestSpline2D(t)); err != nil || ok {
+		t.Errorf("expected splines of different dimension to be incompatible, got %v, %v", ok, err)
+	}
+	if _, err := a.CompatibleWith(nil); err != ErrInvalidNil {
+		t.Errorf("expected ErrInvalidNil, got %v", err)
+	}
+}
+
+func TestSubtract(t *testing.T) {
+	a := newTestSpline1D(t)
+	b := buildTestSpline(t, [][]float64{linspace(0, 2, 21)}, func(x []float64) float64 { return 3 * x[0] })
+
+	diff, err := a.Subtract(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, x := range []float64{0, 0.7, 1.5, 2} {
+		v, err := diff.Eval(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !almostEqual(v, x*x-3*x, 1e-9) {
+			t.Errorf("at %v: expected %v, got %v", x, x*x-3*x, v)
+		}
+	}
+
+	// the operands are left untouched
+	if v, err := a.Eval(1.5); err != nil || !almostEqual(v, 2.25, 1e-9) {
+		t.Errorf("expected a(1.5) = 2.25, got %v, %v", v, err)
+	}
+
+	c := buildTestSpline(t, [][]float64{linspace(0, 2, 11)}, square)
+	if _, err := a.Subtract(c); err != ErrIncompatible {
+		t.Errorf("expected ErrIncompatible, got %v", err)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	bs := newTestSpline1D(t)
+	original, err := bs.GetCoefficients()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the coefficients of x^2 grow from 0 at the left end
+	pruned, indices, err := bs.Prune(0.05)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(indices) == 0 || indices[0] != 0 {
+		t.Fatalf("expected the leftmost coefficients to be pruned, got %v", indices)
+	}
+
+	coeffs, err := pruned.GetCoefficients()
+	if err != nil {
+		t.Fatal(err)
+	}
+	removed := 0.0
+	for i, c := range coeffs {
+		if len(indices) > 0 && indices[0] == i {
+			indices = indices[1:]
+			if c != 0 || math.Abs(original[i]) >= 0.05 {
+				t.Errorf("coefficient %d: %v should not have been pruned to %v", i, original[i], c)
+			}
+			removed += math.Abs(original[i])
+		} else if c != original[i] {
+			t.Errorf("coefficient %d changed from %v to %v", i, original[i], c)
+		}
+	}
+
+	for _, x := range linspace(0, 2, 41) {
+		a, err := bs.Eval(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := pruned.Eval(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(a-b) > removed+1e-12 {
+			t.Errorf("at %v: pruning changed the value by more than %v", x, removed)
+		}
+	}
+
+	if _, _, err := bs.Prune(0); err != ErrInvalidTolerance {
+		t.Errorf("expected ErrInvalidTolerance, got %v", err)
+	}
+}
+
+func TestBlendEval(t *testing.T) {
+	a := newTestSpline1D(t)
+	// 2x on a coarser grid, so the knots differ
+	b := buildTestSpline(t, [][]float64{linspace(0, 2, 5)}, func(x []float64) float64 { return 2 * x[0] })
+
+	for _, w := range []float64{0, 0.25, 1} {
+		v, err := BlendEval(a, b, w, 1.5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := (1-w)*2.25 + w*3; !almostEqual(v, expected, 1e-9) {
+			t.Errorf("w=%v: expected %v, got %v", w, expected, v)
+		}
+	}
+
+	if _, err := BlendEval(a, b, 1.5, 1); err != ErrInvalidBlend {
+		t.Errorf("expected ErrInvalidBlend, got %v", err)
+	}
+	if _, err := BlendEval(a, newTestSpline2D(t), 0.5, 1); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := BlendEval(a, nil, 0.5, 1); err != ErrInvalidNil {
+		t.Errorf("expected ErrInvalidNil, got %v", err)
+	}
+}
+
+func TestEvalEnsemble(t *testing.T) {
+	a := newTestSpline1D(t)
+	b := buildTestSpline(t, [][]float64{linspace(0, 2, 21)}, func(x []float64) float64 { return 3 * x[0] })
+
+	values, err := EvalEnsemble([]*BSpline{a, b, a}, 1.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []float64{2.25, 4.5, 2.25}
+	for i, v := range values {
+		if !almostEqual(v, expected[i], 1e-9) {
+			t.Errorf("member %d: expected %v, got %v", i, expected[i], v)
+		}
+	}
+
+	c := newTestSpline2D(t)
+	_, err = EvalEnsemble([]*BSpline{a, c, nil}, 1.5)
+	if e, ok := err.(ErrEnsembleMember); !ok || e.Index != 1 || e.Err != ErrDimensionMismatch {
+		t.Errorf("expected member 1 to mismatch, got %v", err)
+	}
+	_, err = EvalEnsemble([]*BSpline{a, nil}, 1.5)
+	if e, ok := err.(ErrEnsembleMember); !ok || e.Index != 1 || e.Err != ErrInvalidNil {
+		t.Errorf("expected member 1 to be nil, got %v", err)
+	}
+
+	if values, err := EvalEnsemble(nil, 1.5); err != nil || len(values) != 0 {
+		t.Errorf("expected no values for an empty ensemble, got %v, %v", values, err)
+	}
+}
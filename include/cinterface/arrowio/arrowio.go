@@ -0,0 +1,61 @@
+package arrowio
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	splinter "github.com/bgrimstad/splinter/include/cinterface"
+)
+
+// BSpline is a splinter.BSpline with the Arrow evaluations of this package. The embedded spline is still owned, and
+// must be freed, by the caller.
+type BSpline struct {
+	*splinter.BSpline
+}
+
+// EvalToArrow evaluates the spline at each of the given points and returns a record with one float64 column per
+// variable, named x0, x1 and so on, holding the points, followed by a float64 column y holding the values computed by
+// EvalBatch. The record is allocated on the Go heap and must be released by the caller.
+func (bs BSpline) EvalToArrow(points [][]float64) (arrow.Record, error) {
+	if bs.BSpline == nil {
+		return nil, splinter.ErrInvalidNil
+	}
+
+	n, err := bs.NumVariables()
+	if err != nil {
+		return nil, err
+	}
+	for _, point := range points {
+		if len(point) != n {
+			return nil, splinter.ErrDimensionMismatch
+		}
+	}
+
+	values, err := bs.EvalBatch(points)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]arrow.Field, n+1)
+	for i := 0; i < n; i++ {
+		fields[i] = arrow.Field{Name: fmt.Sprintf("x%d", i), Type: arrow.PrimitiveTypes.Float64}
+	}
+	fields[n] = arrow.Field{Name: "y", Type: arrow.PrimitiveTypes.Float64}
+
+	builder := array.NewRecordBuilder(memory.NewGoAllocator(), arrow.NewSchema(fields, nil))
+	defer builder.Release()
+
+	for i := 0; i < n; i++ {
+		column := builder.Field(i).(*array.Float64Builder)
+		column.Reserve(len(points))
+		for _, point := range points {
+			column.UnsafeAppend(point[i])
+		}
+	}
+	builder.Field(n).(*array.Float64Builder).AppendValues(values, nil)
+
+	return builder.NewRecord(), nil
+}
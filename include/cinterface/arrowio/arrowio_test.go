@@ -0,0 +1,76 @@
+package arrowio
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+
+	splinter "github.com/bgrimstad/splinter/include/cinterface"
+)
+
+func newTestSpline(t *testing.T) *splinter.BSpline {
+	dt, err := splinter.NewDataTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dt.Free()
+
+	var rows [][]float64
+	for i := 0; i <= 10; i++ {
+		for j := 0; j <= 10; j++ {
+			x0, x1 := float64(i)/10, float64(j)/10
+			rows = append(rows, []float64{x0, x1, x0 + 2*x1})
+		}
+	}
+	if err := dt.AddRows(rows); err != nil {
+		t.Fatal(err)
+	}
+	bs, err := splinter.BuildBSpline(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bs
+}
+
+func TestEvalToArrow(t *testing.T) {
+	bs := BSpline{newTestSpline(t)}
+	defer bs.Free()
+
+	points := [][]float64{{0.3, 0.7}, {0, 0}, {1, 0.25}}
+	record, err := bs.EvalToArrow(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer record.Release()
+
+	if record.NumRows() != int64(len(points)) || record.NumCols() != 3 {
+		t.Fatalf("expected %d rows and 3 columns, got %d and %d", len(points), record.NumRows(), record.NumCols())
+	}
+	for i, name := range []string{"x0", "x1", "y"} {
+		field := record.Schema().Field(i)
+		if field.Name != name || field.Type.ID() != arrow.FLOAT64 {
+			t.Errorf("expected float64 column %s, got %s %s", name, field.Type, field.Name)
+		}
+	}
+
+	x0 := record.Column(0).(*array.Float64)
+	x1 := record.Column(1).(*array.Float64)
+	y := record.Column(2).(*array.Float64)
+	for k, point := range points {
+		want, err := bs.Eval(point...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if x0.Value(k) != point[0] || x1.Value(k) != point[1] || y.Value(k) != want {
+			t.Errorf("row %d: expected %v, %v, got %v, %v, %v", k, point, want, x0.Value(k), x1.Value(k), y.Value(k))
+		}
+	}
+
+	if _, err := bs.EvalToArrow([][]float64{{0.5}}); err != splinter.ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := (BSpline{}).EvalToArrow(points); err != splinter.ErrInvalidNil {
+		t.Errorf("expected ErrInvalidNil, got %v", err)
+	}
+}
@@ -0,0 +1,5 @@
+// Package arrowio evaluates splines straight into Apache Arrow record batches, for Arrow-native analytics stacks.
+//
+// The package is a module of its own, so that neither the splinter package nor its module depend on Arrow. Wrap a
+// spline in a BSpline to call EvalToArrow on it.
+package arrowio
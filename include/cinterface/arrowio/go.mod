@@ -0,0 +1,24 @@
+module github.com/bgrimstad/splinter/include/cinterface/arrowio
+
+go 1.21
+
+require (
+	github.com/apache/arrow/go/v17 v17.0.0
+	github.com/bgrimstad/splinter v0.0.0-20261016173520-386fb0fdcb53
+)
+
+require (
+	github.com/goccy/go-json v0.10.3 // indirect
+	github.com/google/flatbuffers v24.3.25+incompatible // indirect
+	github.com/klauspost/cpuid/v2 v2.2.8 // indirect
+	github.com/zeebo/xxh3 v1.0.2 // indirect
+	golang.org/x/exp v0.0.0-20240222234643-814bf88cf225 // indirect
+	golang.org/x/mod v0.18.0 // indirect
+	golang.org/x/sync v0.7.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+	golang.org/x/tools v0.22.0 // indirect
+	golang.org/x/xerrors v0.0.0-20231012003039-104605ab7028 // indirect
+)
+
+// Within this repository, build against the splinter module next to this one rather than the required version.
+replace github.com/bgrimstad/splinter => ../../..
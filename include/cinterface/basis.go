@@ -0,0 +1,267 @@
+package splinter
+
+import (
+	"math"
+	"sort"
+)
+
+// basis1D is a Go port of splinter's univariate B-spline basis, used where evaluating individual basis functions is
+// needed and the C interface only offers the spline value. It assumes a clamped knot vector, which is what the
+// builder produces.
+type basis1D struct {
+	knots  []float64
+	degree int
+}
+
+func (b basis1D) numBasisFunctions() int {
+	return len(b.knots) - b.degree - 1
+}
+
+func (b basis1D) insideSupport(x float64) bool {
+	return b.knots[0] <= x && x <= b.knots[len(b.knots)-1]
+}
+
+// span returns the index i such that knots[i] <= x < knots[i+1]. Like splinter, the right end of the support is moved
+// into the last non-empty interval.
+func (b basis1D) span(x float64) int {
+	if x == b.knots[len(b.knots)-1] {
+		x = math.Nextafter(x, math.Inf(-1))
+	}
+	return sort.Search(len(b.knots), func(i int) bool { return b.knots[i] > x }) - 1
+}
+
+// eval returns the index of the first basis function that may be non-zero at x, and the values of the degree+1
+// basis functions starting at that index. ok is false if x is outside the support, where all basis functions are zero.
+func (b basis1D) eval(x float64) (first int, values []float64, ok bool) {
+	if !b.insideSupport(x) {
+		return 0, nil, false
+	}
+
+	i := b.span(x)
+	if x == b.knots[len(b.knots)-1] {
+		x = math.Nextafter(x, math.Inf(-1))
+	}
+	return i - b.degree, b.evalSpan(x, i), true
+}
+
+// evalExtended is like eval, but continues the polynomial pieces of the first and last knot spans beyond the
+// support instead of returning zero there.
+func (b basis1D) evalExtended(x float64) (first int, values []float64) {
+	var i int
+	switch {
+	case x < b.knots[0]:
+		i = b.span(b.knots[0])
+	case x > b.knots[len(b.knots)-1]:
+		i = b.span(b.knots[len(b.knots)-1])
+	default:
+		first, values, _ = b.eval(x)
+		return first, values
+	}
+	return i - b.degree, b.evalSpan(x, i)
+}
+
+// evalSpan evaluates the degree+1 basis functions that are non-zero on knot span i at x, using the polynomial pieces
+// of that span even if x lies outside it.
+func (b basis1D) evalSpan(x float64, i int) []float64 {
+	p := b.degree
+
+	// Algorithm A2.2 from Piegl and Tiller, The NURBS Book
+	values := make([]float64, p+1)
+	left := make([]float64, p+1)
+	right := make([]float64, p+1)
+	values[0] = 1
+	for j := 1; j <= p; j++ {
+		left[j] = x - b.knots[i+1-j]
+		right[j] = b.knots[i+j] - x
+		saved := 0.0
+		for r := 0; r < j; r++ {
+			temp := values[r] / (right[r+1] + left[j-r])
+			values[r] = saved + right[r+1]*temp
+			saved = left[j-r] * temp
+		}
+		values[j] = saved
+	}
+	return values
+}
+
+// evalSpanDerivs evaluates the degree+1 basis functions that are non-zero on knot span i and their derivatives at x,
+// returning derivs[k][j] for the k-th derivative of function j, k = 0..n. Derivatives above the degree are zero.
+func (b basis1D) evalSpanDerivs(x float64, i, n int) [][]float64 {
+	p := b.degree
+	derivs := make([][]float64, n+1)
+	for k := range derivs {
+		derivs[k] = make([]float64, p+1)
+	}
+	if n > p {
+		n = p
+	}
+
+	// Algorithm A2.3 from Piegl and Tiller, The NURBS Book: ndu holds the basis functions in its upper triangle and the
+	// knot differences in its lower one
+	ndu := make([][]float64, p+1)
+	for j := range ndu {
+		ndu[j] = make([]float64, p+1)
+	}
+	left := make([]float64, p+1)
+	right := make([]float64, p+1)
+	ndu[0][0] = 1
+	for j := 1; j <= p; j++ {
+		left[j] = x - b.knots[i+1-j]
+		right[j] = b.knots[i+j] - x
+		saved := 0.0
+		for r := 0; r < j; r++ {
+			ndu[j][r] = right[r+1] + left[j-r]
+			temp := ndu[r][j-1] / ndu[j][r]
+			ndu[r][j] = saved + right[r+1]*temp
+			saved = left[j-r] * temp
+		}
+		ndu[j][j] = saved
+	}
+	for j := 0; j <= p; j++ {
+		derivs[0][j] = ndu[j][p]
+	}
+
+	a := [2][]float64{make([]float64, p+1), make([]float64, p+1)}
+	for r := 0; r <= p; r++ {
+		s1, s2 := 0, 1
+		a[0][0] = 1
+		for k := 1; k <= n; k++ {
+			d := 0.0
+			rk, pk := r-k, p-k
+			if r >= k {
+				a[s2][0] = a[s1][0] / ndu[pk+1][rk]
+				d = a[s2][0] * ndu[rk][pk]
+			}
+			j1, j2 := 1, k-1
+			if rk < -1 {
+				j1 = -rk
+			}
+			if r-1 > pk {
+				j2 = p - r
+			}
+			for j := j1; j <= j2; j++ {
+				a[s2][j] = (a[s1][j] - a[s1][j-1]) / ndu[pk+1][rk+j]
+				d += a[s2][j] * ndu[rk+j][pk]
+			}
+			if r <= pk {
+				a[s2][k] = -a[s1][k-1] / ndu[pk+1][r]
+				d += a[s2][k] * ndu[r][pk]
+			}
+			derivs[k][r] = d
+			s1, s2 = s2, s1
+		}
+	}
+
+	factor := float64(p)
+	for k := 1; k <= n; k++ {
+		for j := range derivs[k] {
+			derivs[k][j] *= factor
+		}
+		factor *= float64(p - k)
+	}
+	return derivs
+}
+
+// tensorBasis is the tensor product of one univariate basis per variable. Basis functions are numbered with the last
+// variable varying fastest, matching the order of the spline coefficients.
+type tensorBasis []basis1D
+
+// basis reconstructs the basis of the spline from its knot vectors and degrees.
+func (bs *BSpline) basis() (tensorBasis, error) {
+	knotVectors, err := bs.KnotVectors()
+	if err != nil {
+		return nil, err
+	}
+
+	degrees, err := bs.Degrees()
+	if err != nil {
+		return nil, err
+	}
+
+	basis := make(tensorBasis, len(knotVectors))
+	for i := range basis {
+		basis[i] = basis1D{knots: knotVectors[i], degree: degrees[i]}
+	}
+	return basis, nil
+}
+
+// dims returns the number of basis functions of each variable.
+func (tb tensorBasis) dims() []int {
+	dims := make([]int, len(tb))
+	for i, b := range tb {
+		dims[i] = b.numBasisFunctions()
+	}
+	return dims
+}
+
+func (tb tensorBasis) numBasisFunctions() int {
+	n := 1
+	for _, b := range tb {
+		n *= b.numBasisFunctions()
+	}
+	return n
+}
+
+// eval returns the indices and values of the basis functions that may be non-zero at x. Both are empty if x is
+// outside the support.
+func (tb tensorBasis) eval(x []float64) (indices []int, values []float64) {
+	return tb.evalWith(x, false)
+}
+
+// evalExtended is like eval, but continues the boundary polynomial pieces outside the support, see
+// basis1D.evalExtended.
+func (tb tensorBasis) evalExtended(x []float64) (indices []int, values []float64) {
+	return tb.evalWith(x, true)
+}
+
+func (tb tensorBasis) evalWith(x []float64, extend bool) (indices []int, values []float64) {
+	indices = []int{0}
+	values = []float64{1}
+	for d, b := range tb {
+		var first int
+		var vals []float64
+		if extend {
+			first, vals = b.evalExtended(x[d])
+		} else {
+			var ok bool
+			first, vals, ok = b.eval(x[d])
+			if !ok {
+				return nil, nil
+			}
+		}
+
+		n := b.numBasisFunctions()
+		nextIndices := make([]int, 0, len(indices)*len(vals))
+		nextValues := make([]float64, 0, len(values)*len(vals))
+		for k, idx := range indices {
+			for j, v := range vals {
+				nextIndices = append(nextIndices, idx*n+first+j)
+				nextValues = append(nextValues, values[k]*v)
+			}
+		}
+		indices, values = nextIndices, nextValues
+	}
+	return indices, values
+}
+
+// ActiveBasis returns the indices of the basis functions that are non-zero at the given point, in increasing order.
+// Indices refer to the coefficients as returned by GetCoefficients. For a degree p spline there are at most p+1
+// per variable (fewer on knots); none are active outside the domain.
+func (bs *BSpline) ActiveBasis(vals ...float64) ([]int, error) {
+	basis, err := bs.basis()
+	if err != nil {
+		return nil, err
+	}
+	if len(vals) != len(basis) {
+		return nil, ErrDimensionMismatch
+	}
+
+	indices, values := basis.eval(vals)
+	active := []int{}
+	for i, idx := range indices {
+		if values[i] != 0 {
+			active = append(active, idx)
+		}
+	}
+	return active, nil
+}
@@ -0,0 +1,78 @@
+package splinter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBasisMatchesEval(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	basis, err := bs.basis()
+	if err != nil {
+		t.Fatal(err)
+	}
+	coeffs, err := bs.GetCoefficients()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if basis.numBasisFunctions() != len(coeffs) {
+		t.Fatalf("expected %d basis functions, got %d", len(coeffs), basis.numBasisFunctions())
+	}
+
+	for _, x := range [][]float64{{0, 0}, {0.13, 0.87}, {0.5, 0.25}, {1, 1}, {1, 0.3}} {
+		expected, err := bs.Eval(x...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		indices, values := basis.eval(x)
+		got := 0.0
+		for i, idx := range indices {
+			got += coeffs[idx] * values[i]
+		}
+		if !almostEqual(got, expected, 1e-12) {
+			t.Errorf("at %v: expected %v, got %v", x, expected, got)
+		}
+	}
+
+	if indices, _ := basis.eval([]float64{1.5, 0.5}); len(indices) != 0 {
+		t.Errorf("expected no basis functions outside the support, got %v", indices)
+	}
+}
+
+func TestActiveBasis(t *testing.T) {
+	// one basis function per sample value gives 11 per variable
+	bs := newTestSpline2D(t)
+
+	active, err := bs.ActiveBasis(0.05, 0.95)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []int{}
+	for _, i := range []int{0, 1, 2, 3} {
+		for _, j := range []int{7, 8, 9, 10} {
+			expected = append(expected, i*11+j)
+		}
+	}
+	if !reflect.DeepEqual(active, expected) {
+		t.Errorf("expected %v, got %v", expected, active)
+	}
+
+	// at the left end only the first basis function is non-zero
+	active, err = bs.ActiveBasis(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(active, []int{0}) {
+		t.Errorf("expected [0], got %v", active)
+	}
+
+	active, err = bs.ActiveBasis(2, 0.5)
+	if err != nil || len(active) != 0 {
+		t.Errorf("expected no active basis functions outside the domain, got %v (%v)", active, err)
+	}
+	if _, err := bs.ActiveBasis(0.5); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
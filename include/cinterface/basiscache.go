@@ -0,0 +1,56 @@
+package splinter
+
+// BasisCache holds the values of the basis functions of a spline at fixed points, so that splines sharing its knots
+// can be evaluated there for many coefficient vectors without recomputing the basis, see BSpline.PrecomputeBasis.
+type BasisCache struct {
+	numCoefficients int
+
+	// indices[k] and values[k] are the basis functions that may be non-zero at point k and their values there
+	indices [][]int
+	values  [][]float64
+}
+
+// PrecomputeBasis evaluates the basis functions of the spline at the given points, returning a cache that evaluates
+// any spline with the same degrees and knots at these points from its coefficients alone. Only the basis functions
+// that are non-zero at a point are kept, at most (degree+1)^numVariables per point, so each evaluation is a short
+// dot product. Points outside the domain get no basis functions and evaluate to 0, like splinter does.
+func (bs *BSpline) PrecomputeBasis(points [][]float64) (*BasisCache, error) {
+	basis, err := bs.basis()
+	if err != nil {
+		return nil, err
+	}
+
+	cache := &BasisCache{
+		numCoefficients: basis.numBasisFunctions(),
+		indices:         make([][]int, len(points)),
+		values:          make([][]float64, len(points)),
+	}
+	for k, point := range points {
+		if len(point) != len(basis) {
+			return nil, ErrDimensionMismatch
+		}
+		cache.indices[k], cache.values[k] = basis.eval(point)
+	}
+	return cache, nil
+}
+
+// NumCoefficients returns the number of coefficients Eval expects, which is the number of basis functions.
+func (cache *BasisCache) NumCoefficients() int {
+	return cache.numCoefficients
+}
+
+// Eval returns the values at the cached points of the spline with the given coefficients, ordered like
+// BSpline.GetCoefficients. It returns nil if len(coeffs) is not NumCoefficients.
+func (cache *BasisCache) Eval(coeffs []float64) []float64 {
+	if len(coeffs) != cache.numCoefficients {
+		return nil
+	}
+
+	res := make([]float64, len(cache.indices))
+	for k, indices := range cache.indices {
+		for j, idx := range indices {
+			res[k] += coeffs[idx] * cache.values[k][j]
+		}
+	}
+	return res
+}
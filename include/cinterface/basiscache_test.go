@@ -0,0 +1,56 @@
+package splinter
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBasisCache(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	rng := rand.New(rand.NewSource(1))
+	points := make([][]float64, 50)
+	for k := range points {
+		points[k] = []float64{rng.Float64(), rng.Float64()}
+	}
+
+	cache, err := bs.PrecomputeBasis(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coefficients, err := bs.GetCoefficients()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cache.NumCoefficients() != len(coefficients) {
+		t.Fatalf("expected %d coefficients, got %d", len(coefficients), cache.NumCoefficients())
+	}
+
+	expected, err := bs.evalSamples(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range cache.Eval(coefficients) {
+		if !almostEqual(v, expected[k], 1e-12) {
+			t.Errorf("point %v: expected %v, got %v", points[k], expected[k], v)
+		}
+	}
+
+	// the spline is linear in its coefficients
+	doubled := make([]float64, len(coefficients))
+	for i, c := range coefficients {
+		doubled[i] = 2*c + 1
+	}
+	for k, v := range cache.Eval(doubled) {
+		if !almostEqual(v, 2*expected[k]+1, 1e-12) {
+			t.Errorf("point %v: expected %v, got %v", points[k], 2*expected[k]+1, v)
+		}
+	}
+
+	if cache.Eval(coefficients[1:]) != nil {
+		t.Error("expected nil for the wrong number of coefficients")
+	}
+	if _, err := bs.PrecomputeBasis([][]float64{{0.5}}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
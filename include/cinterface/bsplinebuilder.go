@@ -9,17 +9,71 @@ import "C"
 
 import (
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
 	"runtime"
+	"strconv"
+	"sync"
+	"time"
 	"unsafe"
 )
 
 var (
-	ErrInvalidNil        = errors.New("Expected an object, got a nil")
-	ErrLengthMismatch    = errors.New("Input slices must be of the same size")
-	ErrGotNullPtr        = errors.New("Unexpected NULL return from call")
-	ErrZeroVariables     = errors.New("BSpline returned variable dimension set to 0")
-	ErrDimensionMismatch = errors.New("Input dimension not equal to BSpline's")
-	ErrInvalidBounds     = errors.New("Bounds should contain min and max boundaries (exactly two elements)")
+	ErrInvalidNil           = errors.New("Expected an object, got a nil")
+	ErrLengthMismatch       = errors.New("Input slices must be of the same size")
+	ErrGotNullPtr           = errors.New("Unexpected NULL return from call")
+	ErrZeroVariables        = errors.New("BSpline returned variable dimension set to 0")
+	ErrDimensionMismatch    = errors.New("Input dimension not equal to BSpline's")
+	ErrInvalidBounds        = errors.New("Bounds should contain min and max boundaries (exactly two elements)")
+	ErrEmptyAxis            = errors.New("Grid axes must contain at least one value")
+	ErrZeroDirection        = errors.New("Direction vector must be non-zero")
+	ErrNotUnivariate        = errors.New("Operation requires a BSpline with exactly one variable")
+	ErrNotMonotonic         = errors.New("BSpline is not monotonic over its domain")
+	ErrOutOfRange           = errors.New("Value is outside the range of the BSpline")
+	ErrInvalidTolerance     = errors.New("Tolerance must be positive")
+	ErrInvalidIdentifier    = errors.New("Name is not a valid C identifier")
+	ErrUnsupportedDim       = errors.New("Operation is not supported for this number of variables")
+	ErrIncompatible         = errors.New("BSplines differ in degrees or knot vectors, align their knots first")
+	ErrNegativeDensity      = errors.New("BSpline takes negative values and cannot be used as a density")
+	ErrInvalidCount         = errors.New("Count is outside the allowed range")
+	ErrNoSamples            = errors.New("DataTable has no samples")
+	ErrInvalidLevel         = errors.New("Confidence level must be between 0 and 1")
+	ErrInvalidRange         = errors.New("Range must be positive with a minimum not above its maximum")
+	ErrInvalidFolds         = errors.New("Number of folds must be at least 2 and at most the number of interior samples")
+	ErrInvalidSequence      = errors.New("Unknown sequence kind")
+	ErrNoTrainingData       = errors.New("BSpline does not retain the samples it was built from")
+	ErrWeightedUpdate       = errors.New("Cannot update a BSpline fitted with sample weights")
+	ErrZeroVariance         = errors.New("Input variable is constant across the samples")
+	ErrInvalidSpline        = errors.New("Degrees, knot vectors and coefficients do not describe a valid BSpline")
+	ErrInvalidEncoding      = errors.New("Malformed encoded BSpline")
+	ErrCorruptData          = errors.New("BSpline data is truncated or corrupt")
+	ErrSamplesOutsideBounds = errors.New("Some samples fall outside the bounds")
+	ErrOutsideDomain        = errors.New("Point is outside the domain of the BSpline")
+	ErrInvalidPolicy        = errors.New("Unknown extrapolation policy")
+	ErrInvalidMode          = errors.New("Unknown evaluation mode")
+	ErrRoundTripMismatch    = errors.New("BSpline evaluates differently after a save and load round trip")
+	ErrInvalidFraction      = errors.New("Fraction must be strictly between 0 and 1")
+	ErrInvalidAlpha         = errors.New("Alpha must be positive")
+	ErrNoSmoothing          = errors.New("Operation requires a builder with smoothing enabled")
+	ErrInvalidScale         = errors.New("Scale must be between 0 and 18")
+	ErrOverflow             = errors.New("Value does not fit in the fixed-point range")
+	ErrInvalidSpacing       = errors.New("Knot spacing is not supported by this operation")
+	ErrNotPspline           = errors.New("Operation requires a builder with P-spline smoothing")
+	ErrInvalidDelta         = errors.New("Huber threshold must be positive")
+	ErrInvalidVariable      = errors.New("Variable index is out of range")
+	ErrInvalidPeriod        = errors.New("Period must be positive and at least the range of the samples")
+	ErrInvalidBlend         = errors.New("Blend weight must be between 0 and 1")
+	ErrSingularSystem       = errors.New("The linear system of the fit is singular")
+	ErrInvalidFactor        = errors.New("Factor must be greater than 1")
+	ErrSolveFailed          = errors.New("Failed to solve for the B-spline coefficients")
+	ErrNegativeSamples      = errors.New("Samples have negative responses")
+	ErrInvalidStep          = errors.New("Step must be positive")
+	ErrInvalidFitSpec       = errors.New("Malformed fit specification")
+	ErrDuplicateIndex       = errors.New("Variable index appears more than once")
+	ErrTooManyObjects       = errors.New("Too many live splinter objects")
+	ErrInvalidColumn        = errors.New("Column index is out of range")
+	ErrInvalidDegree        = errors.New("Degree must be between 1 and 5")
 )
 
 type KnotSpacing int
@@ -40,17 +94,50 @@ const (
 
 type BSplineBuilder struct {
 	ptr C.splinter_obj_ptr
+
+	// the C interface is write-only, so we keep the settings passed to splinter around to be able to read them back.
+	// x and y are the samples of the table the builder was created from (splinter copies the table).
+	x      [][]float64
+	y      []float64
+	config BuilderConfig
 }
 
 type DataTable struct {
 	ptr C.splinter_obj_ptr
+
+	// x and y mirror the samples stored in splinter, see Samples. They are replaced, never modified in place, so
+	// builders can hold on to them.
+	x [][]float64
+	y []float64
 }
 
 type BSpline struct {
 	ptr C.splinter_obj_ptr
+
+	// weights are the sample weights the spline was fitted with, see AppliedWeights.
+	weights []float64
+
+	// x, y and config are the samples and settings the spline was built from, kept for Update. They are nil for
+	// splines that were not built by a builder.
+	x      [][]float64
+	y      []float64
+	config *BuilderConfig
+
+	// nonNegative is whether negative values are clamped to zero, see SetNonNegative. Unlike config, it is kept by the
+	// copies made with clone.
+	nonNegative bool
+
+	// fast is the Go-side evaluator used by Eval in EvalFast mode, nil in EvalPrecise mode.
+	fast *fastEvaluator
 }
 
+// splinterMu serializes calls into splinter. splinter reports errors through a process-wide flag that stays set until
+// it is read, so the lock is held across each call and the getErrorIfExists that checks it; otherwise goroutines using
+// splinter at the same time could pick up each other's errors, or clear them before they are seen.
+var splinterMu sync.Mutex
+
 // getErrorIfExists checks splinter for an error in the last call, and returns an error if one happened, nil otherwise.
+// It must be called with splinterMu held.
 func getErrorIfExists() error {
 	if C.splinter_get_error() == 1 {
 		return errors.New(C.GoString(C.splinter_get_error_string()))
@@ -63,6 +150,12 @@ func getErrorIfExists() error {
 ////////////////////
 
 func NewDataTable() (*DataTable, error) {
+	if err := reserveObject(); err != nil {
+		return nil, err
+	}
+
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
 	ptr := C.splinter_datatable_init()
 	err := getErrorIfExists()
 	if err != nil {
@@ -71,24 +164,39 @@ func NewDataTable() (*DataTable, error) {
 			C.splinter_datatable_delete(ptr)
 		}
 
+		releaseObject()
 		return nil, err
 	}
 
 	res := new(DataTable)
 	res.ptr = ptr
-	runtime.SetFinalizer(res, func(dt *DataTable) { C.splinter_datatable_delete(dt.ptr) })
+	runtime.SetFinalizer(res, finalizeDataTable)
+	trackCreated()
 	return res, nil
 }
 
+func finalizeDataTable(dt *DataTable) {
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
+	C.splinter_datatable_delete(dt.ptr)
+	releaseObject()
+}
+
 func (dt *DataTable) Free() {
 	runtime.SetFinalizer(dt, nil)
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
 	C.splinter_datatable_delete(dt.ptr)
+	if dt.ptr != nil {
+		releaseObject()
+		trackFreed()
+	}
 	dt.ptr = nil
 }
 
 // AddColumns adds the given columns to the datatable.
 // The columns must be the same length, otherwise returns ErrLengthMismatch
-func (dt DataTable) AddColumns(columns ...[]float64) error {
+func (dt *DataTable) AddColumns(columns ...[]float64) error {
 	// if user didn't add anything, return nil
 	if len(columns) == 0 {
 		return nil
@@ -109,9 +217,17 @@ func (dt DataTable) AddColumns(columns ...[]float64) error {
 	}
 
 	// now add the samples
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
 	C.splinter_datatable_add_samples_col_major(dt.ptr, (*C.double)(unsafe.Pointer(&concat[0])),
 		C.int(n), C.int(len(columns)-1))
-	return getErrorIfExists()
+	err := getErrorIfExists()
+	if err != nil {
+		return err
+	}
+
+	dt.mirrorColumns(columns)
+	return nil
 }
 
 ////////////////////
@@ -122,7 +238,12 @@ func NewBSplineBuilder(table *DataTable) (*BSplineBuilder, error) {
 	if table == nil {
 		return nil, ErrInvalidNil
 	}
+	if err := reserveObject(); err != nil {
+		return nil, err
+	}
 
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
 	ptr := C.splinter_bspline_builder_init(table.ptr)
 	err := getErrorIfExists()
 	if err != nil {
@@ -131,46 +252,106 @@ func NewBSplineBuilder(table *DataTable) (*BSplineBuilder, error) {
 			C.splinter_bspline_builder_delete(ptr)
 		}
 
+		releaseObject()
 		return nil, err
 	}
 
 	res := new(BSplineBuilder)
 	res.ptr = ptr
-	runtime.SetFinalizer(res, func(builder *BSplineBuilder) { C.splinter_bspline_builder_delete(builder.ptr) })
+	res.x = table.x
+	res.y = table.y
+	res.config.Alpha = 0.1
+	runtime.SetFinalizer(res, finalizeBSplineBuilder)
+	trackCreated()
 	return res, nil
 }
 
+func finalizeBSplineBuilder(builder *BSplineBuilder) {
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
+	C.splinter_bspline_builder_delete(builder.ptr)
+	releaseObject()
+}
+
 func (builder *BSplineBuilder) Free() {
 	runtime.SetFinalizer(builder, nil)
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
 	C.splinter_bspline_builder_delete(builder.ptr)
+	if builder.ptr != nil {
+		releaseObject()
+		trackFreed()
+	}
 	builder.ptr = nil
 }
 
 func (builder *BSplineBuilder) KnotSpacing(ks KnotSpacing) error {
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
 	C.splinter_bspline_builder_set_knot_spacing(builder.ptr, C.int(ks))
-	return getErrorIfExists()
+	err := getErrorIfExists()
+	if err != nil {
+		return err
+	}
+
+	builder.config.KnotSpacing = ks
+	return nil
 }
 
 func (builder *BSplineBuilder) Smoothing(s Smoothing) error {
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
 	C.splinter_bspline_builder_set_smoothing(builder.ptr, C.int(s))
-	return getErrorIfExists()
+	err := getErrorIfExists()
+	if err != nil {
+		return err
+	}
+
+	builder.config.Smoothing = s
+	return nil
 }
 
 func (builder *BSplineBuilder) Alpha(alpha float64) error {
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
 	C.splinter_bspline_builder_set_alpha(builder.ptr, C.double(alpha))
-	return getErrorIfExists()
+	err := getErrorIfExists()
+	if err != nil {
+		return err
+	}
+
+	builder.config.Alpha = alpha
+	return nil
 }
 
 func (builder *BSplineBuilder) Padding(padding float64) error {
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
 	C.splinter_bspline_builder_set_padding(builder.ptr, C.double(padding))
-	return getErrorIfExists()
+	err := getErrorIfExists()
+	if err != nil {
+		return err
+	}
+
+	builder.config.Padding = padding
+	return nil
 }
 
 func (builder *BSplineBuilder) Weights(weights []float64) error {
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
 	C.splinter_bspline_builder_set_weights(builder.ptr, (*C.double)(&weights[0]), C.int(len(weights)))
-	return getErrorIfExists()
+	err := getErrorIfExists()
+	if err != nil {
+		return err
+	}
+
+	builder.config.Weights = append([]float64(nil), weights...)
+	return nil
 }
 
+// Bounds sets the domain of the spline, as one [min, max] pair per variable. Bounds that exclude some of the
+// builder's samples are rejected with ErrSamplesOutsideBounds; use DataTable.FilterBounds to drop such samples first.
 func (builder *BSplineBuilder) Bounds(bounds [][]float64) error {
 	minBounds := make([]float64, len(bounds))
 	maxBounds := make([]float64, len(bounds))
@@ -182,13 +363,43 @@ func (builder *BSplineBuilder) Bounds(bounds [][]float64) error {
 		maxBounds[i] = b[1]
 	}
 
+	if len(builder.y) > 0 {
+		if len(bounds) != len(builder.x[0]) {
+			return ErrDimensionMismatch
+		}
+		for _, x := range builder.x {
+			if !insideBounds(x, bounds) {
+				return ErrSamplesOutsideBounds
+			}
+		}
+	}
+
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
 	C.splinter_bspline_builder_set_bounds(builder.ptr, (*C.double)(&minBounds[0]), (*C.double)(&maxBounds[0]), C.int(len(bounds)))
-	return getErrorIfExists()
+	err := getErrorIfExists()
+	if err != nil {
+		return err
+	}
+
+	builder.config.Bounds = make([][]float64, len(bounds))
+	for i := range bounds {
+		builder.config.Bounds[i] = []float64{minBounds[i], maxBounds[i]}
+	}
+	return nil
 }
 
 func (builder *BSplineBuilder) HfsIters(iters uint) error {
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
 	C.splinter_bspline_builder_set_hfs_iters(builder.ptr, C.uint(iters))
-	return getErrorIfExists()
+	err := getErrorIfExists()
+	if err != nil {
+		return err
+	}
+
+	builder.config.HfsIters = iters
+	return nil
 }
 
 func (builder *BSplineBuilder) NumBasisFunctions(n []int) error {
@@ -199,11 +410,84 @@ func (builder *BSplineBuilder) NumBasisFunctions(n []int) error {
 		nC[i] = C.int(x)
 	}
 
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
 	C.splinter_bspline_builder_set_num_basis_functions(builder.ptr, &nC[0], C.int(len(nC)))
-	return getErrorIfExists()
+	err := getErrorIfExists()
+	if err != nil {
+		return err
+	}
+
+	builder.config.NumBasisFunctions = append([]int(nil), n...)
+	return nil
+}
+
+// minBasisDegree and maxBasisDegree bound the basis degrees Degree accepts. splinter also builds piecewise constant
+// splines of degree 0, but the package reads saved splines and computes knot averages assuming a degree of at least 1.
+const (
+	minBasisDegree = 1
+	maxBasisDegree = 5
+)
+
+// Degree sets the degree of the basis functions of each variable, one entry per variable, such as 3 for cubic (the
+// default) or 1 for linear. Degrees outside 1 to 5 are rejected with ErrInvalidDegree, and splinter rejects a slice
+// whose length differs from the number of variables.
+func (builder *BSplineBuilder) Degree(degrees []int) error {
+	if len(degrees) == 0 {
+		return ErrDimensionMismatch
+	}
+
+	degreesC := make([]C.uint, len(degrees))
+	for i, d := range degrees {
+		if d < minBasisDegree || d > maxBasisDegree {
+			return ErrInvalidDegree
+		}
+		degreesC[i] = C.uint(d)
+	}
+
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
+	C.splinter_bspline_builder_set_degree(builder.ptr, &degreesC[0], C.int(len(degreesC)))
+	err := getErrorIfExists()
+	if err != nil {
+		return err
+	}
+
+	builder.config.Degrees = append([]int(nil), degrees...)
+	return nil
+}
+
+// libraryName is the name of the SPLINTER library the package links against, as in the LDFLAGS above.
+const libraryName = "splinter-static-3-0"
+
+// Version identifies the SPLINTER library the package is linked against, for bug reports and compatibility checks.
+// The C interface has no version call, so this is the name of the library from the build flags, which carries its
+// version (libsplinter-static-3-0 for SPLINTER 3.0).
+func Version() string {
+	return libraryName
+}
+
+// SetMaxBuildThreads limits the number of threads the native linear algebra may use while building splines.
+//
+// SPLINTER has no threading API of its own; Eigen only parallelizes when the library was compiled with OpenMP, in which
+// case the thread count is read from OMP_NUM_THREADS when the OpenMP runtime starts. This sets that variable, so it
+// must be called before the first Build to take effect, and it is a no-op for the default (single threaded) build.
+// A value of n <= 0 removes the limit.
+func SetMaxBuildThreads(n int) {
+	if n <= 0 {
+		os.Unsetenv("OMP_NUM_THREADS")
+		return
+	}
+	os.Setenv("OMP_NUM_THREADS", strconv.Itoa(n))
 }
 
 func (builder *BSplineBuilder) Build() (*BSpline, error) {
+	if err := reserveObject(); err != nil {
+		return nil, err
+	}
+
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
 	ptr := C.splinter_bspline_builder_build(builder.ptr)
 	err := getErrorIfExists()
 	if err != nil {
@@ -212,12 +496,23 @@ func (builder *BSplineBuilder) Build() (*BSpline, error) {
 			C.splinter_bspline_delete(ptr)
 		}
 
+		releaseObject()
 		return nil, err
 	}
 
 	res := new(BSpline)
 	res.ptr = ptr
-	runtime.SetFinalizer(res, func(bs *BSpline) { C.splinter_bspline_delete(bs.ptr) })
+	runtime.SetFinalizer(res, finalizeBSpline)
+	trackCreated()
+
+	// splinter only uses the weights for P-splines
+	if builder.config.Smoothing == SmoothingPspline {
+		res.weights = builder.config.Weights
+	}
+
+	config := builder.config
+	res.x, res.y, res.config = builder.x, builder.y, &config
+	res.nonNegative = config.NonNegative
 	return res, nil
 }
 
@@ -225,13 +520,43 @@ func (builder *BSplineBuilder) Build() (*BSpline, error) {
 //// BSpline
 /////////////
 
+func finalizeBSpline(bs *BSpline) {
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
+	C.splinter_bspline_delete(bs.ptr)
+	releaseObject()
+}
+
 func (bs *BSpline) Free() {
 	runtime.SetFinalizer(bs, nil)
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
 	C.splinter_bspline_delete(bs.ptr)
+	if bs.ptr != nil {
+		releaseObject()
+		trackFreed()
+	}
 	bs.ptr = nil
 }
 
+// Eval evaluates the spline at a point. Splines built with BSplineBuilder.NonNegative have negative values clamped to
+// zero.
 func (bs *BSpline) Eval(vals ...float64) (float64, error) {
+	if bs.fast != nil {
+		return bs.fast.eval(vals)
+	}
+
+	v, err := bs.evalPoint(vals)
+	if err == nil && v < 0 && bs.nonNegative {
+		v = clampNegative(vals, v)
+	}
+	return v, err
+}
+
+// evalPoint evaluates the spline at a point in splinter, without the clamp of BSplineBuilder.NonNegative.
+func (bs *BSpline) evalPoint(vals []float64) (float64, error) {
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
 	n := C.splinter_bspline_get_num_variables(bs.ptr)
 	if n == 0 {
 		return 0, ErrZeroVariables
@@ -256,7 +581,137 @@ func (bs *BSpline) Eval(vals ...float64) (float64, error) {
 	return *(*float64)(unsafe.Pointer(arr)), nil
 }
 
+// ErrInvalidPoint is returned by EvalBatch and the other batch evaluations for the point at Index that cannot be
+// evaluated, with Err the reason.
+type ErrInvalidPoint struct {
+	Index int
+	Err   error
+}
+
+func (e ErrInvalidPoint) Error() string {
+	return fmt.Sprintf("Point %d: %v", e.Index, e.Err)
+}
+
+// EvalBatch evaluates the spline at each of the given points, returning the values in the same order. All points are
+// passed to splinter in one contiguous buffer and evaluated in a single call, which is much faster than calling Eval
+// per point. A point with the wrong number of variables is reported as an ErrInvalidPoint wrapping
+// ErrDimensionMismatch, and nothing is evaluated.
+func (bs *BSpline) EvalBatch(points [][]float64) ([]float64, error) {
+	n, err := bs.NumVariables()
+	if err != nil {
+		return nil, err
+	}
+	for i, point := range points {
+		if len(point) != n {
+			return nil, ErrInvalidPoint{Index: i, Err: ErrDimensionMismatch}
+		}
+	}
+	return bs.evalSamples(points)
+}
+
+// EvalTimed evaluates the spline like Eval, and also reports the time spent in the calls into splinter, excluding the
+// validation done on the Go side.
+func (bs *BSpline) EvalTimed(vals ...float64) (value float64, dur time.Duration, err error) {
+	n, err := bs.NumVariables()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if len(vals) != n {
+		return 0, 0, ErrDimensionMismatch
+	}
+
+	start := time.Now()
+	res, err := bs.evalRowMajor(vals, 1)
+	dur = time.Since(start)
+	if err != nil {
+		return 0, dur, err
+	}
+
+	return res[0], dur, nil
+}
+
+// EvalJacobian evaluates the gradient of the spline at the given point, one partial derivative per variable.
+func (bs *BSpline) EvalJacobian(vals ...float64) ([]float64, error) {
+	n, err := bs.NumVariables()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(vals) != n {
+		return nil, ErrDimensionMismatch
+	}
+
+	return bs.evalJacobianRowMajor(vals, 1)
+}
+
+// EvalJacobianBatch evaluates the gradient of the spline at each of the given points in a single call into splinter,
+// returning one gradient per point. Points are validated like in EvalBatch.
+func (bs *BSpline) EvalJacobianBatch(points [][]float64) ([][]float64, error) {
+	n, err := bs.NumVariables()
+	if err != nil {
+		return nil, err
+	}
+
+	flat := make([]float64, 0, len(points)*n)
+	for i, point := range points {
+		if len(point) != n {
+			return nil, ErrInvalidPoint{Index: i, Err: ErrDimensionMismatch}
+		}
+		flat = append(flat, point...)
+	}
+
+	jacobians, err := bs.evalJacobianRowMajor(flat, len(points))
+	if err != nil {
+		return nil, err
+	}
+	grads := make([][]float64, len(points))
+	for k := range grads {
+		grads[k] = jacobians[k*n : (k+1)*n : (k+1)*n]
+	}
+	return grads, nil
+}
+
+// EvalHessian evaluates the (numVariables x numVariables) Hessian of the spline at the given point.
+func (bs *BSpline) EvalHessian(vals ...float64) ([][]float64, error) {
+	n, err := bs.NumVariables()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(vals) != n {
+		return nil, ErrDimensionMismatch
+	}
+
+	flat, err := bs.evalHessianRowMajor(vals, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	hessian := make([][]float64, n)
+	for i := range hessian {
+		hessian[i] = flat[i*n : (i+1)*n]
+	}
+	return hessian, nil
+}
+
+// GetDomain returns the domain of the spline as one [min, max] pair per variable, in the same layout Bounds accepts.
+func (bs *BSpline) GetDomain() ([][]float64, error) {
+	knotVectors, err := bs.KnotVectors()
+	if err != nil {
+		return nil, err
+	}
+
+	domain := make([][]float64, len(knotVectors))
+	for i, knots := range knotVectors {
+		domain[i] = []float64{knots[0], knots[len(knots)-1]}
+	}
+	return domain, nil
+}
+
 func (bs *BSpline) GetCoefficients() ([]float64, error) {
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
 	n := C.splinter_bspline_get_num_coefficients(bs.ptr)
 	if n < 0 {
 		return nil, getErrorIfExists()
@@ -286,7 +741,474 @@ func (bs *BSpline) GetCoefficients() ([]float64, error) {
 	return coeff, nil
 }
 
+// ControlPoints returns the control points of the spline, one row per coefficient holding the knot averages of its
+// basis function, which are the inputs the coefficient is attached to, followed by the coefficient itself. The rows are
+// in the order of GetCoefficients.
+func (bs *BSpline) ControlPoints() ([][]float64, error) {
+	n, err := bs.NumVariables()
+	if err != nil {
+		return nil, err
+	}
+
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
+	rows := int(C.splinter_bspline_get_num_coefficients(bs.ptr))
+	err = getErrorIfExists()
+	if err != nil {
+		return nil, err
+	}
+
+	arr := C.splinter_bspline_get_control_points(bs.ptr)
+	if arr == nil {
+		if err := getErrorIfExists(); err != nil {
+			return nil, err
+		}
+		return nil, ErrGotNullPtr
+	}
+	defer C.free(unsafe.Pointer(arr))
+
+	err = getErrorIfExists()
+	if err != nil {
+		return nil, err
+	}
+
+	cols := n + 1
+	flat := copyDoubles(arr, rows*cols)
+	points := make([][]float64, rows)
+	for i := range points {
+		points[i] = flat[i*cols : (i+1)*cols : (i+1)*cols]
+	}
+	return points, nil
+}
+
+// NumCoefficients returns the number of coefficients of the spline, which is the length of GetCoefficients and of the
+// slice SetCoefficients expects.
+func (bs *BSpline) NumCoefficients() (int, error) {
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
+	n := int(C.splinter_bspline_get_num_coefficients(bs.ptr))
+	err := getErrorIfExists()
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
 func (bs *BSpline) SetCoefficients(coeffs []float64) error {
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
 	C.splinter_bspline_set_coefficients(bs.ptr, (*C.double)(unsafe.Pointer(&coeffs[0])), C.int(len(coeffs)))
+	err := getErrorIfExists()
+	if err != nil {
+		return err
+	}
+
+	if bs.fast != nil {
+		bs.fast.coefficients = append([]float64(nil), coeffs...)
+	}
+	return nil
+}
+
+// NumVariables returns the number of input variables of the spline, which is the number of values Eval expects, or
+// ErrZeroVariables if it has none.
+func (bs *BSpline) NumVariables() (int, error) {
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
+	n := int(C.splinter_bspline_get_num_variables(bs.ptr))
+	if n == 0 {
+		return 0, ErrZeroVariables
+	}
+	return n, nil
+}
+
+// evalRowMajor evaluates the spline at numPoints points stored consecutively (row major) in x, using a single call
+// into splinter. x must hold exactly numPoints*numVariables values. Like Eval, it clamps negative values of splines
+// built with BSplineBuilder.NonNegative.
+func (bs *BSpline) evalRowMajor(x []float64, numPoints int) ([]float64, error) {
+	values, err := bs.evalRowMajorRaw(x, numPoints)
+	if err != nil || numPoints == 0 || !bs.nonNegative {
+		return values, err
+	}
+
+	n := len(x) / numPoints
+	for k, v := range values {
+		if v < 0 {
+			values[k] = clampNegative(x[k*n:(k+1)*n], v)
+		}
+	}
+	return values, nil
+}
+
+// evalRowMajorRaw is evalRowMajor without the clamp of BSplineBuilder.NonNegative.
+func (bs *BSpline) evalRowMajorRaw(x []float64, numPoints int) ([]float64, error) {
+	if numPoints == 0 {
+		return []float64{}, nil
+	}
+
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
+	arr := C.splinter_bspline_eval_row_major(bs.ptr, (*C.double)(unsafe.Pointer(&x[0])), C.int(len(x)))
+	if arr == nil {
+		if err := getErrorIfExists(); err != nil {
+			return nil, err
+		}
+		return nil, ErrGotNullPtr
+	}
+	defer C.free(unsafe.Pointer(arr))
+
+	err := getErrorIfExists()
+	if err != nil {
+		return nil, err
+	}
+
+	return copyDoubles(arr, numPoints), nil
+}
+
+// copyDoubles copies n doubles from C memory into a Go-managed slice.
+func copyDoubles(arr *C.double, n int) []float64 {
+	// based on https://github.com/golang/go/wiki/cgo#turning-c-arrays-into-go-slices
+	inCMemory := (*[1 << 28]float64)(unsafe.Pointer(arr))[:n:n]
+
+	res := make([]float64, 0, n)
+	res = append(res, inCMemory...)
+	return res
+}
+
+// evalJacobianRowMajor evaluates the gradient at numPoints points stored consecutively (row major) in x, using a
+// single call into splinter. The result holds numVariables values per point.
+func (bs *BSpline) evalJacobianRowMajor(x []float64, numPoints int) ([]float64, error) {
+	if numPoints == 0 {
+		return []float64{}, nil
+	}
+
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
+	arr := C.splinter_bspline_eval_jacobian_row_major(bs.ptr, (*C.double)(unsafe.Pointer(&x[0])), C.int(len(x)))
+	if arr == nil {
+		if err := getErrorIfExists(); err != nil {
+			return nil, err
+		}
+		return nil, ErrGotNullPtr
+	}
+	defer C.free(unsafe.Pointer(arr))
+
+	err := getErrorIfExists()
+	if err != nil {
+		return nil, err
+	}
+
+	return copyDoubles(arr, len(x)), nil
+}
+
+// evalHessianRowMajor evaluates the Hessian at numPoints points stored consecutively (row major) in x, using a single
+// call into splinter. The result holds numVariables*numVariables values per point.
+func (bs *BSpline) evalHessianRowMajor(x []float64, numPoints int) ([]float64, error) {
+	if numPoints == 0 {
+		return []float64{}, nil
+	}
+
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
+	arr := C.splinter_bspline_eval_hessian_row_major(bs.ptr, (*C.double)(unsafe.Pointer(&x[0])), C.int(len(x)))
+	if arr == nil {
+		if err := getErrorIfExists(); err != nil {
+			return nil, err
+		}
+		return nil, ErrGotNullPtr
+	}
+	defer C.free(unsafe.Pointer(arr))
+
+	err := getErrorIfExists()
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(x) / numPoints
+	return copyDoubles(arr, n*n*numPoints), nil
+}
+
+// KnotVectors returns the knot vector of each variable, copied into Go-managed memory. Each vector is non-decreasing,
+// with its end knots repeated degree+1 times at the bounds of the domain (see GetDomain), so the interior knots show
+// where the chosen KnotSpacing placed the breaks between polynomial pieces.
+func (bs *BSpline) KnotVectors() ([][]float64, error) {
+	n, err := bs.NumVariables()
+	if err != nil {
+		return nil, err
+	}
+
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
+	sizes := C.splinter_bspline_get_knot_vector_sizes(bs.ptr)
+	if sizes == nil {
+		if err := getErrorIfExists(); err != nil {
+			return nil, err
+		}
+		return nil, ErrGotNullPtr
+	}
+	defer C.free(unsafe.Pointer(sizes))
+
+	err = getErrorIfExists()
+	if err != nil {
+		return nil, err
+	}
+
+	sizesInCMemory := (*[1 << 28]C.int)(unsafe.Pointer(sizes))[:n:n]
+	total := 0
+	for _, size := range sizesInCMemory {
+		total += int(size)
+	}
+
+	arr := C.splinter_bspline_get_knot_vectors(bs.ptr)
+	if arr == nil {
+		if err := getErrorIfExists(); err != nil {
+			return nil, err
+		}
+		return nil, ErrGotNullPtr
+	}
+	defer C.free(unsafe.Pointer(arr))
+
+	err = getErrorIfExists()
+	if err != nil {
+		return nil, err
+	}
+
+	flat := copyDoubles(arr, total)
+	knotVectors := make([][]float64, n)
+	for i, size := range sizesInCMemory {
+		knotVectors[i] = flat[:size:size]
+		flat = flat[size:]
+	}
+	return knotVectors, nil
+}
+
+// Degrees returns the degree of the basis functions of each variable, 3 for the default cubic splines.
+func (bs *BSpline) Degrees() ([]int, error) {
+	n, err := bs.NumVariables()
+	if err != nil {
+		return nil, err
+	}
+
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
+	arr := C.splinter_bspline_get_basis_degrees(bs.ptr)
+	if arr == nil {
+		if err := getErrorIfExists(); err != nil {
+			return nil, err
+		}
+		return nil, ErrGotNullPtr
+	}
+	defer C.free(unsafe.Pointer(arr))
+
+	err = getErrorIfExists()
+	if err != nil {
+		return nil, err
+	}
+
+	degreesInCMemory := (*[1 << 28]C.int)(unsafe.Pointer(arr))[:n:n]
+	degrees := make([]int, n)
+	for i, d := range degreesInCMemory {
+		degrees[i] = int(d)
+	}
+	return degrees, nil
+}
+
+// newBSpline wraps a pointer returned from splinter, taking care of cleanup if splinter reported an error. It must be
+// called with splinterMu held.
+func newBSpline(ptr C.splinter_obj_ptr) (*BSpline, error) {
+	err := getErrorIfExists()
+	if err != nil {
+		// make sure we clean up if we got a pointer and an error
+		if ptr != nil {
+			C.splinter_bspline_delete(ptr)
+		}
+
+		return nil, err
+	}
+
+	if ptr == nil {
+		return nil, ErrGotNullPtr
+	}
+
+	// splinter has already allocated the spline, so it is released again if it is over the limit
+	if err := reserveObject(); err != nil {
+		C.splinter_bspline_delete(ptr)
+		return nil, err
+	}
+
+	res := new(BSpline)
+	res.ptr = ptr
+	runtime.SetFinalizer(res, finalizeBSpline)
+	trackCreated()
+	return res, nil
+}
+
+// Save stores the spline in filename in splinter's binary format, to be loaded with LoadBSpline, for instance by
+// another process. Only the spline itself is stored, not the samples and settings it was built from, so Update and
+// the methods that need the training data are not available on the loaded copy, nor the clamp of
+// BSplineBuilder.NonNegative.
+func (bs *BSpline) Save(filename string) error {
+	cFilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cFilename))
+
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
+	C.splinter_bspline_save(bs.ptr, cFilename)
 	return getErrorIfExists()
 }
+
+// LoadBSpline loads a spline stored by Save. splinter does not validate what it loads, and may crash on a corrupt
+// file, so the file is read and checked on the Go side first: a missing file fails with the error from the file
+// system, data that is not a spline in a supported format with an ErrUnsupportedVersion, and a spline file that is
+// truncated or damaged with ErrCorruptData.
+func LoadBSpline(filename string) (*BSpline, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFormat(data); err != nil {
+		return nil, err
+	}
+
+	cFilename := C.CString(filename)
+	defer C.free(unsafe.Pointer(cFilename))
+
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
+	return newBSpline(C.splinter_bspline_load_init(cFilename))
+}
+
+// clone returns an independent copy of the spline, clamping negative values like the spline does. The C interface has
+// no copy function, so the spline makes a round trip through splinter's file format.
+func (bs *BSpline) clone() (*BSpline, error) {
+	data, err := bs.saveBytes()
+	if err != nil {
+		return nil, err
+	}
+	res, err := loadBSplineBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	res.nonNegative = bs.nonNegative
+	return res, nil
+}
+
+// saveBytes returns the spline in splinter's binary format. splinter can only save to files, so a temporary file is
+// used.
+func (bs *BSpline) saveBytes() ([]byte, error) {
+	f, err := ioutil.TempFile("", "splinter-bspline")
+	if err != nil {
+		return nil, err
+	}
+	filename := f.Name()
+	f.Close()
+	defer os.Remove(filename)
+
+	err = bs.Save(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadFile(filename)
+}
+
+// loadBSplineBytes loads a spline from data in splinter's binary format, going through a temporary file.
+func loadBSplineBytes(data []byte) (*BSpline, error) {
+	f, err := ioutil.TempFile("", "splinter-bspline")
+	if err != nil {
+		return nil, err
+	}
+	filename := f.Name()
+	defer os.Remove(filename)
+
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return LoadBSpline(filename)
+}
+
+// adopt makes bs take over the splinter object of other, freeing the one bs held. other must not be used afterwards.
+func (bs *BSpline) adopt(other *BSpline) {
+	runtime.SetFinalizer(other, nil)
+	runtime.SetFinalizer(bs, nil)
+	if bs.ptr != nil {
+		splinterMu.Lock()
+		C.splinter_bspline_delete(bs.ptr)
+		splinterMu.Unlock()
+		releaseObject()
+		trackFreed()
+	}
+
+	*bs = *other
+	other.ptr = nil
+	runtime.SetFinalizer(bs, finalizeBSpline)
+}
+
+// replaceSamples swaps the splinter table for a new one holding the given samples, which must already be in splinter
+// order (a subset of the current samples, for instance). splinter tables cannot remove samples, so this is how a table
+// is rebuilt.
+func (dt *DataTable) replaceSamples(x [][]float64, y []float64) error {
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
+	ptr := C.splinter_datatable_init()
+	err := getErrorIfExists()
+	if err != nil {
+		if ptr != nil {
+			C.splinter_datatable_delete(ptr)
+		}
+		return err
+	}
+
+	if len(y) > 0 {
+		dim := len(x[0])
+		rows := make([]float64, 0, len(y)*(dim+1))
+		for i := range y {
+			rows = append(rows, x[i]...)
+			rows = append(rows, y[i])
+		}
+
+		C.splinter_datatable_add_samples_row_major(ptr, (*C.double)(unsafe.Pointer(&rows[0])), C.int(len(y)), C.int(dim))
+		err = getErrorIfExists()
+		if err != nil {
+			C.splinter_datatable_delete(ptr)
+			return err
+		}
+	}
+
+	C.splinter_datatable_delete(dt.ptr)
+	dt.ptr = ptr
+	dt.x = x
+	dt.y = y
+	return nil
+}
+
+// replaceTable swaps the splinter builder for one created from table, since builders copy their table on creation.
+// The settings of the old builder are not carried over; the builder is reset to splinter's defaults.
+func (builder *BSplineBuilder) replaceTable(table *DataTable) error {
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
+	ptr := C.splinter_bspline_builder_init(table.ptr)
+	err := getErrorIfExists()
+	if err != nil {
+		if ptr != nil {
+			C.splinter_bspline_builder_delete(ptr)
+		}
+		return err
+	}
+
+	C.splinter_bspline_builder_delete(builder.ptr)
+	builder.ptr = ptr
+	builder.x = table.x
+	builder.y = table.y
+	builder.config = BuilderConfig{Alpha: 0.1}
+	return nil
+}
+
+// numVariables returns the number of input variables of the samples in the table, 0 if it is empty.
+func (dt *DataTable) numVariables() int {
+	splinterMu.Lock()
+	defer splinterMu.Unlock()
+	return int(C.splinter_datatable_get_num_variables(dt.ptr))
+}
@@ -1,6 +1,13 @@
 package splinter
 
 import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 )
 
@@ -17,3 +24,456 @@ func TestBSplinebuilder(t *testing.T) {
 
 	_ = builder
 }
+
+// buildTestSpline fits a default (cubic) spline to f sampled on the Cartesian product of axes.
+func buildTestSpline(t testing.TB, axes [][]float64, f func(x []float64) float64) *BSpline {
+	t.Helper()
+
+	columns := make([][]float64, len(axes)+1)
+	point := make([]float64, len(axes))
+	for k := 0; k < gridSize(axes); k++ {
+		gridPoint(axes, k, point)
+		for i, x := range point {
+			columns[i] = append(columns[i], x)
+		}
+		columns[len(axes)] = append(columns[len(axes)], f(point))
+	}
+
+	dt, err := NewDataTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dt.AddColumns(columns...); err != nil {
+		t.Fatal(err)
+	}
+
+	builder, err := NewBSplineBuilder(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bs, err := builder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bs
+}
+
+// square is reproduced exactly by a cubic spline on [0, 2].
+func square(x []float64) float64 {
+	return x[0] * x[0]
+}
+
+// bilinearish is reproduced exactly by a bicubic spline on [0, 1]^2.
+func bilinearish(x []float64) float64 {
+	return x[0]*x[0] + x[0]*x[1]
+}
+
+func newTestSpline1D(t *testing.T) *BSpline {
+	return buildTestSpline(t, [][]float64{linspace(0, 2, 21)}, square)
+}
+
+func newTestSpline2D(t *testing.T) *BSpline {
+	return buildTestSpline(t, [][]float64{linspace(0, 1, 11), linspace(0, 1, 11)}, bilinearish)
+}
+
+func almostEqual(a, b, tol float64) bool {
+	return math.Abs(a-b) <= tol
+}
+
+func TestSetMaxBuildThreads(t *testing.T) {
+	defer os.Unsetenv("OMP_NUM_THREADS")
+
+	SetMaxBuildThreads(2)
+	if v := os.Getenv("OMP_NUM_THREADS"); v != "2" {
+		t.Errorf("expected OMP_NUM_THREADS=2, got %q", v)
+	}
+
+	SetMaxBuildThreads(0)
+	if _, ok := os.LookupEnv("OMP_NUM_THREADS"); ok {
+		t.Error("expected OMP_NUM_THREADS to be unset")
+	}
+}
+
+func TestGetDomain(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	domain, err := bs.GetDomain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(domain) != 2 {
+		t.Fatalf("expected 2 dimensions, got %d", len(domain))
+	}
+	for i, d := range domain {
+		if d[0] != 0 || d[1] != 1 {
+			t.Errorf("dimension %d: expected [0 1], got %v", i, d)
+		}
+	}
+}
+
+func TestEvalTimed(t *testing.T) {
+	bs := newTestSpline1D(t)
+
+	v, dur, err := bs.EvalTimed(1.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual(v, 2.25, 1e-9) {
+		t.Errorf("expected 2.25, got %v", v)
+	}
+	if dur <= 0 {
+		t.Errorf("expected a positive duration, got %v", dur)
+	}
+
+	if _, _, err := bs.EvalTimed(1, 2); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
+func TestBoundsExcludingSamples(t *testing.T) {
+	dt, err := newTestSpline1D(t).Resample([][]float64{linspace(0, 2, 11)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	builder, err := NewBSplineBuilder(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := builder.Bounds([][]float64{{0, 1.5}}); err != ErrSamplesOutsideBounds {
+		t.Errorf("expected ErrSamplesOutsideBounds, got %v", err)
+	}
+	if builder.config.Bounds != nil {
+		t.Errorf("rejected bounds should not be recorded, got %v", builder.config.Bounds)
+	}
+	if err := builder.Bounds([][]float64{{-1, 3}}); err != nil {
+		t.Errorf("expected bounds containing the samples to be accepted, got %v", err)
+	}
+	if err := builder.Bounds([][]float64{{-1, 3}, {0, 1}}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
+func TestVersion(t *testing.T) {
+	if v := Version(); v != "splinter-static-3-0" {
+		t.Errorf("expected splinter-static-3-0, got %q", v)
+	}
+}
+
+func TestEvalBatch(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	points := [][]float64{{0, 0}, {0.5, 0.25}, {1, 1}}
+	values, err := bs.EvalBatch(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != len(points) {
+		t.Fatalf("expected %d values, got %d", len(points), len(values))
+	}
+	for i, p := range points {
+		if !almostEqual(values[i], bilinearish(p), 1e-9) {
+			t.Errorf("point %v: expected %v, got %v", p, bilinearish(p), values[i])
+		}
+	}
+
+	_, err = bs.EvalBatch([][]float64{{0, 0}, {0.5, 0.5}, {0.5}})
+	if e, ok := err.(ErrInvalidPoint); !ok || e.Index != 2 || e.Err != ErrDimensionMismatch {
+		t.Errorf("expected point 2 to mismatch, got %v", err)
+	}
+
+	if values, err := bs.EvalBatch(nil); err != nil || len(values) != 0 {
+		t.Errorf("expected no values for no points, got %v, %v", values, err)
+	}
+}
+
+func TestEvalJacobian(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	// the gradient of x0^2 + x0*x1 is (2x0 + x1, x0)
+	grad, err := bs.EvalJacobian(0.5, 0.25)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(grad) != 2 || !almostEqual(grad[0], 1.25, 1e-9) || !almostEqual(grad[1], 0.5, 1e-9) {
+		t.Errorf("expected [1.25 0.5], got %v", grad)
+	}
+	if _, err := bs.EvalJacobian(0.5); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+
+	points := [][]float64{{0.5, 0.25}, {1, 0}}
+	grads, err := bs.EvalJacobianBatch(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, p := range points {
+		if !almostEqual(grads[k][0], 2*p[0]+p[1], 1e-9) || !almostEqual(grads[k][1], p[0], 1e-9) {
+			t.Errorf("point %v: unexpected gradient %v", p, grads[k])
+		}
+	}
+	_, err = bs.EvalJacobianBatch([][]float64{{0.5, 0.25}, {1}})
+	if e, ok := err.(ErrInvalidPoint); !ok || e.Index != 1 || e.Err != ErrDimensionMismatch {
+		t.Errorf("expected point 1 to mismatch, got %v", err)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "splinter-save")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "spline.bin")
+
+	bs := newTestSpline2D(t)
+	if err := bs.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadBSpline(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loaded.Free()
+	for _, p := range [][]float64{{0, 0}, {0.3, 0.7}, {1, 1}} {
+		v, err := loaded.Eval(p...)
+		if err != nil || !almostEqual(v, bilinearish(p), 1e-9) {
+			t.Errorf("point %v: expected %v, got %v, %v", p, bilinearish(p), v, err)
+		}
+	}
+
+	if _, err := LoadBSpline(filepath.Join(dir, "missing.bin")); !os.IsNotExist(err) {
+		t.Errorf("expected a not-exist error, got %v", err)
+	}
+	if err := ioutil.WriteFile(path, []byte("not a spline"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadBSpline(path); err == nil {
+		t.Error("expected an error for a corrupt file")
+	} else if _, ok := err.(ErrUnsupportedVersion); !ok {
+		t.Errorf("expected ErrUnsupportedVersion, got %v", err)
+	}
+}
+
+func TestConcurrentEval(t *testing.T) {
+	bs := newTestSpline1D(t)
+	other := newTestSpline1D(t)
+
+	// goroutines evaluating the spline must not see the errors raised by the failing calls running next to them
+	var wg sync.WaitGroup
+	errs := make(chan error, 8*100)
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				if g%2 == 1 {
+					if err := other.SetCoefficients([]float64{1}); err == nil {
+						errs <- errors.New("expected an error for a wrong number of coefficients")
+					}
+					continue
+				}
+
+				x := float64(i) / 50
+				v, err := bs.Eval(x)
+				if err != nil {
+					errs <- err
+				} else if !almostEqual(v, x*x, 1e-9) {
+					errs <- fmt.Errorf("x=%v: expected %v, got %v", x, x*x, v)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestGetters(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	if n, err := bs.NumVariables(); err != nil || n != 2 {
+		t.Errorf("expected 2 variables, got %v, %v", n, err)
+	}
+
+	coeffs, err := bs.GetCoefficients()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := bs.NumCoefficients(); err != nil || n != len(coeffs) {
+		t.Errorf("expected %d coefficients, got %v, %v", len(coeffs), n, err)
+	}
+
+	degrees, err := bs.Degrees()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(degrees) != 2 || degrees[0] != 3 || degrees[1] != 3 {
+		t.Errorf("expected [3 3], got %v", degrees)
+	}
+}
+
+func TestKnotVectors(t *testing.T) {
+	bs := newTestSpline1D(t)
+
+	knotVectors, err := bs.KnotVectors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(knotVectors) != 1 {
+		t.Fatalf("expected 1 knot vector, got %d", len(knotVectors))
+	}
+
+	// a cubic spline has one knot per coefficient plus degree+1
+	knots := knotVectors[0]
+	n, err := bs.NumCoefficients()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(knots) != n+4 {
+		t.Fatalf("expected %d knots, got %d", n+4, len(knots))
+	}
+	for i := 0; i < 4; i++ {
+		if knots[i] != 0 || knots[len(knots)-1-i] != 2 {
+			t.Errorf("expected end knots repeated at 0 and 2, got %v", knots)
+			break
+		}
+	}
+	for i := 1; i < len(knots); i++ {
+		if knots[i] < knots[i-1] {
+			t.Errorf("knots decrease at %d: %v", i, knots)
+		}
+	}
+}
+
+func TestDegree(t *testing.T) {
+	dt, err := newTestSpline2D(t).Resample([][]float64{linspace(0, 1, 11), linspace(0, 1, 11)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	builder, err := NewBSplineBuilder(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := builder.Degree([]int{3, 6}); err != ErrInvalidDegree {
+		t.Errorf("expected ErrInvalidDegree, got %v", err)
+	}
+	if err := builder.Degree([]int{3}); err == nil {
+		t.Error("expected an error for too few degrees")
+	}
+	if err := builder.Degree([]int{0, 3}); err != ErrInvalidDegree {
+		t.Errorf("expected ErrInvalidDegree for degree 0, got %v", err)
+	}
+	if err := builder.Degree(nil); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+
+	if err := builder.Degree([]int{3, 1}); err != nil {
+		t.Fatal(err)
+	}
+	bs, err := builder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	degrees, err := bs.Degrees()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(degrees) != 2 || degrees[0] != 3 || degrees[1] != 1 {
+		t.Errorf("expected [3 1], got %v", degrees)
+	}
+	// x0^2 + x0*x1 is linear in x1, so it is still reproduced
+	if v, err := bs.Eval(0.5, 0.25); err != nil || !almostEqual(v, 0.375, 1e-9) {
+		t.Errorf("expected 0.375, got %v, %v", v, err)
+	}
+}
+
+func TestControlPoints(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	points, err := bs.ControlPoints()
+	if err != nil {
+		t.Fatal(err)
+	}
+	coeffs, err := bs.GetCoefficients()
+	if err != nil {
+		t.Fatal(err)
+	}
+	degrees, err := bs.Degrees()
+	if err != nil {
+		t.Fatal(err)
+	}
+	knotVectors, err := bs.KnotVectors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	averages := knotAverages(degrees, knotVectors)
+
+	if len(points) != len(coeffs) {
+		t.Fatalf("expected %d control points, got %d", len(coeffs), len(points))
+	}
+	for k, p := range points {
+		if len(p) != 3 {
+			t.Fatalf("control point %d: expected 3 values, got %v", k, p)
+		}
+		if !almostEqual(p[0], averages[k][0], 1e-12) || !almostEqual(p[1], averages[k][1], 1e-12) || p[2] != coeffs[k] {
+			t.Errorf("control point %d: expected %v and %v, got %v", k, averages[k], coeffs[k], p)
+		}
+	}
+}
+
+func TestDegreeSaveLoad(t *testing.T) {
+	dt, err := newTestSpline2D(t).Resample([][]float64{linspace(0, 1, 11), linspace(0, 1, 11)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	builder, err := NewBSplineBuilder(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer builder.Free()
+
+	// the lowest degree Degree accepts must survive a save and load
+	if err := builder.Degree([]int{minBasisDegree, minBasisDegree}); err != nil {
+		t.Fatal(err)
+	}
+	bs, err := builder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bs.Free()
+
+	dir, err := ioutil.TempDir("", "splinter-degree")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "spline.bin")
+	if err := bs.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadBSpline(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loaded.Free()
+
+	degrees, err := loaded.Degrees()
+	if err != nil || len(degrees) != 2 || degrees[0] != minBasisDegree || degrees[1] != minBasisDegree {
+		t.Errorf("expected degrees [%d %d], got %v, %v", minBasisDegree, minBasisDegree, degrees, err)
+	}
+	for _, p := range [][]float64{{0, 0}, {0.35, 0.65}, {1, 1}} {
+		want, err := bs.Eval(p...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := loaded.Eval(p...); err != nil || got != want {
+			t.Errorf("point %v: expected %v, got %v, %v", p, want, got, err)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package splinter
+
+import (
+	"math"
+	"strings"
+)
+
+// solveFailureMessage is part of the message of the error splinter reports when it cannot solve for the coefficients,
+// as opposed to rejecting its input.
+const solveFailureMessage = "Failed to solve for B-spline coefficients"
+
+// isSolveFailure reports whether err is splinter failing to solve the linear system of a fit.
+func isSolveFailure(err error) bool {
+	return err == ErrSolveFailed || strings.Contains(err.Error(), solveFailureMessage)
+}
+
+// BuildRobust builds the spline like Build, but when splinter fails to solve the (ill-conditioned) linear system of
+// the fit, or the solution is not finite, it retries with alpha multiplied by factor, starting from startAlpha and
+// giving up past maxAlpha, which must be finite. It returns the spline and the alpha it was built with. Errors other
+// than solve failures, such as invalid settings, are returned immediately; if every alpha fails, ErrSolveFailed is
+// returned.
+//
+// Alpha only affects fits with smoothing, so the builder must use SmoothingIdentity or SmoothingPspline. The
+// builder's alpha is restored before returning.
+func (builder *BSplineBuilder) BuildRobust(startAlpha, maxAlpha, factor float64) (bs *BSpline, usedAlpha float64, err error) {
+	if !(startAlpha > 0) {
+		return nil, 0, ErrInvalidAlpha
+	}
+	if !(maxAlpha >= startAlpha) || math.IsInf(maxAlpha, 1) {
+		return nil, 0, ErrInvalidRange
+	}
+	if !(factor > 1) {
+		return nil, 0, ErrInvalidFactor
+	}
+	if builder.config.Smoothing == SmoothingNone {
+		return nil, 0, ErrNoSmoothing
+	}
+
+	prior := builder.config.Alpha
+	defer builder.Alpha(prior)
+
+	for alpha := startAlpha; alpha <= maxAlpha; alpha *= factor {
+		err = builder.Alpha(alpha)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		bs, err = builder.Build()
+		if err == nil {
+			err = checkFinite(bs)
+			if err != nil {
+				bs.Free()
+			}
+		}
+		if err == nil {
+			return bs, alpha, nil
+		}
+		if !isSolveFailure(err) {
+			return nil, 0, err
+		}
+	}
+	return nil, 0, ErrSolveFailed
+}
+
+// checkFinite returns ErrSolveFailed if any of the coefficients of the spline is NaN or infinite.
+func checkFinite(bs *BSpline) error {
+	coeffs, err := bs.GetCoefficients()
+	if err != nil {
+		return err
+	}
+	for _, c := range coeffs {
+		if math.IsNaN(c) || math.IsInf(c, 0) {
+			return ErrSolveFailed
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package splinter
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestBuildRobust(t *testing.T) {
+	dt := noisyTable(t)
+	builder, err := NewBSplineBuilder(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.Configure(BuilderConfig{Smoothing: SmoothingPspline, Alpha: 0.3}); err != nil {
+		t.Fatal(err)
+	}
+
+	bs, alpha, err := builder.BuildRobust(1e-6, 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alpha != 1e-6 || bs.config.Alpha != 1e-6 {
+		t.Errorf("expected the first alpha to succeed, got %v", alpha)
+	}
+	if builder.config.Alpha != 0.3 {
+		t.Errorf("expected the builder's alpha to be restored, got %v", builder.config.Alpha)
+	}
+
+	// input errors are not retried
+	if err := builder.NumBasisFunctions([]int{2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.KnotSpacing(KnotSpacingEquidistant); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := builder.BuildRobust(1e-6, 1, 10); err == nil || isSolveFailure(err) {
+		t.Errorf("expected an input error, got %v", err)
+	}
+
+	if _, _, err := builder.BuildRobust(0, 1, 10); err != ErrInvalidAlpha {
+		t.Errorf("expected ErrInvalidAlpha, got %v", err)
+	}
+	if _, _, err := builder.BuildRobust(1, 0.1, 10); err != ErrInvalidRange {
+		t.Errorf("expected ErrInvalidRange, got %v", err)
+	}
+	if _, _, err := builder.BuildRobust(1, math.Inf(1), 10); err != ErrInvalidRange {
+		t.Errorf("expected ErrInvalidRange for an infinite maxAlpha, got %v", err)
+	}
+	if _, _, err := builder.BuildRobust(1e-6, 1, 1); err != ErrInvalidFactor {
+		t.Errorf("expected ErrInvalidFactor, got %v", err)
+	}
+	if err := builder.Smoothing(SmoothingNone); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := builder.BuildRobust(1e-6, 1, 10); err != ErrNoSmoothing {
+		t.Errorf("expected ErrNoSmoothing, got %v", err)
+	}
+}
+
+func TestIsSolveFailure(t *testing.T) {
+	solve := errors.New("BSpline::Builder::computeBSplineCoefficients: Failed to solve for B-spline coefficients.")
+	input := errors.New("BSpline::Builder::getSecondOrderDifferenceMatrix: Need at least three coefficients/basis function per variable.")
+	if !isSolveFailure(solve) || !isSolveFailure(ErrSolveFailed) || isSolveFailure(input) {
+		t.Errorf("solve failures are not told apart from input errors")
+	}
+}
@@ -0,0 +1,155 @@
+package splinter
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var cIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// GenerateCHeader writes a self-contained C header that evaluates the spline without linking splinter, for targets
+// such as firmware. The header holds the knot vectors and coefficients as static arrays, and defines
+// `static double funcName(double x0, ...)` which evaluates the basis functions with the Cox-de Boor recursion and
+// returns 0 outside the domain, like Eval. Results match Eval up to floating point rounding.
+//
+// Only splines with one or two variables are supported.
+func (bs *BSpline) GenerateCHeader(w io.Writer, funcName string) error {
+	if !cIdentifier.MatchString(funcName) {
+		return ErrInvalidIdentifier
+	}
+
+	basis, err := bs.basis()
+	if err != nil {
+		return err
+	}
+
+	if len(basis) > 2 {
+		return ErrUnsupportedDim
+	}
+
+	coeffs, err := bs.GetCoefficients()
+	if err != nil {
+		return err
+	}
+
+	maxDegree := 0
+	for _, b := range basis {
+		if b.degree > maxDegree {
+			maxDegree = b.degree
+		}
+	}
+
+	var buf bytes.Buffer
+	guard := strings.ToUpper(funcName) + "_H"
+	fmt.Fprintf(&buf, "/* Code generated by the splinter Go bindings. DO NOT EDIT. */\n\n")
+	fmt.Fprintf(&buf, "#ifndef %s\n#define %s\n\n", guard, guard)
+
+	for d, b := range basis {
+		writeCArray(&buf, fmt.Sprintf("%s_knots%d", funcName, d), b.knots)
+	}
+	writeCArray(&buf, funcName+"_coeffs", coeffs)
+
+	fmt.Fprintf(&buf, `/* Evaluates the degree+1 basis functions that may be non-zero at x into values, and returns the index of the
+ * first one, or -1 if x is outside the knot vector. */
+static int %[1]s_basis(const double *knots, int num_knots, int degree, double x, double *values)
+{
+    double left[%[2]d], right[%[2]d], saved, temp;
+    int lo = 0, hi = num_knots, mid, i, j, r;
+
+    if (x < knots[0] || x > knots[num_knots - 1])
+        return -1;
+
+    /* find i such that knots[i] <= x < knots[i+1], using the last non-empty interval at the right end */
+    while (lo < hi)
+    {
+        mid = (lo + hi) / 2;
+        if (knots[mid] > x)
+            hi = mid;
+        else
+            lo = mid + 1;
+    }
+    i = lo - 1;
+    while (i > 0 && knots[i] == knots[num_knots - 1])
+        i--;
+
+    values[0] = 1.0;
+    for (j = 1; j <= degree; j++)
+    {
+        left[j] = x - knots[i + 1 - j];
+        right[j] = knots[i + j] - x;
+        saved = 0.0;
+        for (r = 0; r < j; r++)
+        {
+            temp = values[r] / (right[r + 1] + left[j - r]);
+            values[r] = saved + right[r + 1] * temp;
+            saved = left[j - r] * temp;
+        }
+        values[j] = saved;
+    }
+
+    return i - degree;
+}
+
+`, funcName, maxDegree+1)
+
+	params := make([]string, len(basis))
+	for d := range basis {
+		params[d] = fmt.Sprintf("double x%d", d)
+	}
+	fmt.Fprintf(&buf, "static double %s(%s)\n{\n", funcName, strings.Join(params, ", "))
+	fmt.Fprintf(&buf, "    double sum = 0.0;\n")
+	for d, b := range basis {
+		fmt.Fprintf(&buf, "    double b%d[%d];\n", d, b.degree+1)
+	}
+	fmt.Fprintf(&buf, "    int i, j;\n")
+	for d, b := range basis {
+		fmt.Fprintf(&buf, "    int f%[1]d = %[2]s_basis(%[2]s_knots%[1]d, %[3]d, %[4]d, x%[1]d, b%[1]d);\n",
+			d, funcName, len(b.knots), b.degree)
+	}
+	if len(basis) == 1 {
+		fmt.Fprintf(&buf, `
+    if (f0 < 0)
+        return 0.0;
+    for (i = 0; i <= %[2]d; i++)
+        sum += %[1]s_coeffs[f0 + i] * b0[i];
+    (void) j;
+    return sum;
+}
+`, funcName, basis[0].degree)
+	} else {
+		fmt.Fprintf(&buf, `
+    if (f0 < 0 || f1 < 0)
+        return 0.0;
+    for (i = 0; i <= %[2]d; i++)
+        for (j = 0; j <= %[3]d; j++)
+            sum += %[1]s_coeffs[(f0 + i) * %[4]d + f1 + j] * b0[i] * b1[j];
+    return sum;
+}
+`, funcName, basis[0].degree, basis[1].degree, basis[1].numBasisFunctions())
+	}
+	fmt.Fprintf(&buf, "\n#endif /* %s */\n", guard)
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// writeCArray writes values as a static const double array definition, with enough digits to round-trip exactly.
+func writeCArray(buf *bytes.Buffer, name string, values []float64) {
+	fmt.Fprintf(buf, "static const double %s[%d] = {", name, len(values))
+	for i, v := range values {
+		if i%4 == 0 {
+			buf.WriteString("\n    ")
+		} else {
+			buf.WriteString(" ")
+		}
+		buf.WriteString(strconv.FormatFloat(v, 'g', 17, 64))
+		if i != len(values)-1 {
+			buf.WriteString(",")
+		}
+	}
+	buf.WriteString("\n};\n\n")
+}
@@ -0,0 +1,117 @@
+package splinter
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestGenerateCHeader(t *testing.T) {
+	var buf bytes.Buffer
+	if err := newTestSpline2D(t).GenerateCHeader(&buf, "surface"); err != nil {
+		t.Fatal(err)
+	}
+
+	header := buf.String()
+	for _, s := range []string{
+		"#ifndef SURFACE_H",
+		"static const double surface_knots0[",
+		"static const double surface_knots1[",
+		"static const double surface_coeffs[121]",
+		"static double surface(double x0, double x1)",
+	} {
+		if !strings.Contains(header, s) {
+			t.Errorf("expected header to contain %q", s)
+		}
+	}
+
+	if err := newTestSpline1D(t).GenerateCHeader(&buf, "1curve"); err != ErrInvalidIdentifier {
+		t.Errorf("expected ErrInvalidIdentifier, got %v", err)
+	}
+
+	cube := buildTestSpline(t, [][]float64{linspace(0, 1, 4), linspace(0, 1, 4), linspace(0, 1, 4)}, square)
+	if err := cube.GenerateCHeader(&buf, "cube"); err != ErrUnsupportedDim {
+		t.Errorf("expected ErrUnsupportedDim, got %v", err)
+	}
+}
+
+// TestGenerateCHeaderMatchesEval compiles the generated header with the system C compiler and checks that the function
+// it defines gives the values of Eval, inside and outside the domain.
+func TestGenerateCHeaderMatchesEval(t *testing.T) {
+	cc, err := exec.LookPath("cc")
+	if err != nil {
+		t.Skip("no C compiler")
+	}
+
+	dir, err := ioutil.TempDir("", "splinter-cheader")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for name, c := range map[string]struct {
+		bs     *BSpline
+		points [][]float64
+	}{
+		"curve":   {newTestSpline1D(t), [][]float64{{0}, {0.3}, {1}, {1.55}, {2}, {-0.5}, {2.5}}},
+		"surface": {newTestSpline2D(t), [][]float64{{0, 0}, {0.3, 0.7}, {1, 0.25}, {0.5, 1}, {0.2, 1.5}, {-1, 0.5}}},
+	} {
+		var header bytes.Buffer
+		if err := c.bs.GenerateCHeader(&header, name); err != nil {
+			t.Fatal(err)
+		}
+
+		var main bytes.Buffer
+		fmt.Fprintf(&main, "#include <stdio.h>\n#include \"%s.h\"\n\nint main(void)\n{\n", name)
+		for _, x := range c.points {
+			args := make([]string, len(x))
+			for i, v := range x {
+				args[i] = strconv.FormatFloat(v, 'g', -1, 64)
+			}
+			fmt.Fprintf(&main, "    printf(\"%%.17g\\n\", %s(%s));\n", name, strings.Join(args, ", "))
+		}
+		fmt.Fprintf(&main, "    return 0;\n}\n")
+
+		source := filepath.Join(dir, name+".c")
+		binary := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(filepath.Join(dir, name+".h"), header.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(source, main.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+		compile := exec.Command(cc, "-std=c99", "-Wall", "-Werror", "-o", binary, source, "-lm")
+		if out, err := compile.CombinedOutput(); err != nil {
+			t.Fatalf("%s: compiling the header failed: %v\n%s", name, err, out)
+		}
+		out, err := exec.Command(binary).Output()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		lines := strings.Fields(string(out))
+		if len(lines) != len(c.points) {
+			t.Fatalf("%s: expected %d values, got %q", name, len(c.points), out)
+		}
+		for k, x := range c.points {
+			got, err := strconv.ParseFloat(lines[k], 64)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := c.bs.Eval(x...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !almostEqual(got, want, 1e-12*math.Max(1, math.Abs(want))) {
+				t.Errorf("%s at %v: expected %v, got %v", name, x, want, got)
+			}
+		}
+	}
+}
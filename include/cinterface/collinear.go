@@ -0,0 +1,134 @@
+package splinter
+
+import (
+	"math"
+	"sort"
+)
+
+// collinear reports whether columns a and b of x are affine functions of one another, to within tol on their
+// absolute correlation. Two constant columns are collinear; a constant and a varying one are not.
+func collinear(x [][]float64, a, b int, tol float64) bool {
+	n := float64(len(x))
+	meanA, meanB := 0.0, 0.0
+	for _, row := range x {
+		meanA += row[a] / n
+		meanB += row[b] / n
+	}
+
+	var cov, varA, varB float64
+	for _, row := range x {
+		da, db := row[a]-meanA, row[b]-meanB
+		cov += da * db
+		varA += da * da
+		varB += db * db
+	}
+
+	if varA == 0 || varB == 0 {
+		return varA == 0 && varB == 0
+	}
+	return math.Abs(cov)/math.Sqrt(varA*varB) >= 1-tol
+}
+
+// DropCollinearInputs removes input variables that are collinear with an earlier one, that is whose absolute
+// correlation with it is at least 1-tol, since they make the fit rank deficient. The builder is recreated with the
+// reduced samples, keeping its settings (per-variable settings lose the dropped variables). The returned indices of
+// the dropped variables are in terms of the original inputs; callers must remove them from the points they evaluate
+// the resulting spline at.
+//
+// Samples that coincide once the variables are dropped are merged, keeping the first in splinter order.
+func (builder *BSplineBuilder) DropCollinearInputs(tol float64) ([]int, error) {
+	if tol <= 0 || tol >= 1 {
+		return nil, ErrInvalidTolerance
+	}
+	if len(builder.y) == 0 {
+		return nil, ErrNoSamples
+	}
+
+	dim := len(builder.x[0])
+	dropped := []int{}
+	kept := []int{}
+	for j := 0; j < dim; j++ {
+		isCollinear := false
+		for _, i := range kept {
+			if collinear(builder.x, i, j, tol) {
+				isCollinear = true
+				break
+			}
+		}
+
+		if isCollinear {
+			dropped = append(dropped, j)
+		} else {
+			kept = append(kept, j)
+		}
+	}
+
+	if len(dropped) == 0 {
+		return dropped, nil
+	}
+
+	// order the reduced samples the way splinter will, so the weights can follow them
+	reduced := make([][]float64, len(builder.y))
+	for i, row := range builder.x {
+		reduced[i] = make([]float64, len(kept))
+		for k, j := range kept {
+			reduced[i][k] = row[j]
+		}
+	}
+	order := make([]int, len(reduced))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return lessX(reduced[order[a]], reduced[order[b]]) })
+
+	x := make([][]float64, 0, len(order))
+	y := make([]float64, 0, len(order))
+	cfg := builder.config
+	cfg.Weights = nil
+	for _, i := range order {
+		if len(x) > 0 && equalX(x[len(x)-1], reduced[i]) {
+			continue
+		}
+
+		x = append(x, reduced[i])
+		y = append(y, builder.y[i])
+		if len(builder.config.Weights) == len(builder.y) {
+			cfg.Weights = append(cfg.Weights, builder.config.Weights[i])
+		}
+	}
+
+	if len(cfg.Bounds) == dim {
+		cfg.Bounds = nil
+		for _, j := range kept {
+			cfg.Bounds = append(cfg.Bounds, builder.config.Bounds[j])
+		}
+	}
+	if len(cfg.Degrees) == dim {
+		cfg.Degrees = nil
+		for _, j := range kept {
+			cfg.Degrees = append(cfg.Degrees, builder.config.Degrees[j])
+		}
+	}
+	if len(cfg.NumBasisFunctions) == dim {
+		cfg.NumBasisFunctions = nil
+		for _, j := range kept {
+			cfg.NumBasisFunctions = append(cfg.NumBasisFunctions, builder.config.NumBasisFunctions[j])
+		}
+	}
+
+	dt, err := newDataTableFromSamples(x, y)
+	if err != nil {
+		return nil, err
+	}
+	defer dt.Free()
+
+	err = builder.replaceTable(dt)
+	if err != nil {
+		return nil, err
+	}
+	err = builder.Configure(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return dropped, nil
+}
@@ -0,0 +1,65 @@
+package splinter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDropCollinearInputs(t *testing.T) {
+	// x1 is an affine function of x0, x2 varies independently
+	var x0, x1, x2, y []float64
+	for _, a := range linspace(0, 1, 6) {
+		for _, b := range linspace(0, 1, 6) {
+			x0 = append(x0, a)
+			x1 = append(x1, 1-2*a)
+			x2 = append(x2, b)
+			y = append(y, a*a+b)
+		}
+	}
+
+	dt, err := NewDataTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dt.AddColumns(x0, x1, x2, y); err != nil {
+		t.Fatal(err)
+	}
+	builder, err := NewBSplineBuilder(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.NumBasisFunctions([]int{6, 7, 8}); err != nil {
+		t.Fatal(err)
+	}
+
+	dropped, err := builder.DropCollinearInputs(1e-9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dropped, []int{1}) {
+		t.Fatalf("expected to drop input 1, got %v", dropped)
+	}
+	if !reflect.DeepEqual(builder.config.NumBasisFunctions, []int{6, 8}) {
+		t.Errorf("expected per-variable settings to follow, got %v", builder.config.NumBasisFunctions)
+	}
+
+	bs, err := builder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := bs.Eval(0.4, 0.6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual(got, 0.4*0.4+0.6, 1e-9) {
+		t.Errorf("expected %v, got %v", 0.4*0.4+0.6, got)
+	}
+
+	dropped, err = builder.DropCollinearInputs(1e-9)
+	if err != nil || len(dropped) != 0 {
+		t.Errorf("expected nothing left to drop, got %v (%v)", dropped, err)
+	}
+	if _, err := builder.DropCollinearInputs(0); err != ErrInvalidTolerance {
+		t.Errorf("expected ErrInvalidTolerance, got %v", err)
+	}
+}
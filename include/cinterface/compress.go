@@ -0,0 +1,47 @@
+package splinter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+)
+
+// SaveCompressed saves the spline to path in splinter's binary format compressed with gzip, which shrinks large
+// splines considerably since neighbouring coefficients and knots tend to be similar. Like SaveFitSpec, the file is
+// written to a temporary file and renamed into place. As with Save, the clamp of BSplineBuilder.NonNegative is not
+// stored.
+func (bs *BSpline) SaveCompressed(path string) error {
+	data, err := bs.saveBytes()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, buf.Bytes())
+}
+
+// LoadCompressed loads a spline saved by SaveCompressed. The decompressed data is checked like any other spline file,
+// so data from unsupported SPLINTER versions is reported as ErrUnsupportedVersion and damaged data as ErrCorruptData.
+func LoadCompressed(path string) (*BSpline, error) {
+	compressed, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadAll(zr)
+	if err != nil {
+		return nil, err
+	}
+	return loadBSplineBytes(data)
+}
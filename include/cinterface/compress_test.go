@@ -0,0 +1,64 @@
+package splinter
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSaveCompressed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "splinter-compressed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "spline.gz")
+
+	bs := newTestSpline2D(t)
+	if err := bs.SaveCompressed(path); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := bs.saveBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() >= int64(len(raw)) {
+		t.Errorf("expected compression, got %d bytes from %d", info.Size(), len(raw))
+	}
+
+	loaded, err := LoadCompressed(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loaded.Free()
+
+	a, err := bs.GetCoefficients()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := loaded.GetCoefficients()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Error("expected the loaded spline to have the same coefficients")
+	}
+	if v, err := loaded.Eval(0.5, 0.5); err != nil || !almostEqual(v, 0.5, 1e-9) {
+		t.Errorf("unexpected value %v, %v", v, err)
+	}
+
+	// uncompressed data is not accepted
+	if err := ioutil.WriteFile(path, raw, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCompressed(path); err == nil {
+		t.Error("expected an error for uncompressed data")
+	}
+}
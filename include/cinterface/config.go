@@ -0,0 +1,157 @@
+package splinter
+
+import (
+	"runtime"
+	"sync"
+)
+
+// BuilderConfig holds the settings of a BSplineBuilder, so that the same kind of fit can be repeated on different
+// data. Fields left at their zero value keep splinter's defaults; in particular Alpha is only set when non-zero.
+type BuilderConfig struct {
+	KnotSpacing       KnotSpacing `json:"knotSpacing"`
+	Smoothing         Smoothing   `json:"smoothing"`
+	Alpha             float64     `json:"alpha"`
+	Padding           float64     `json:"padding"`
+	Weights           []float64   `json:"weights,omitempty"`
+	Bounds            [][]float64 `json:"bounds,omitempty"`
+	HfsIters          uint        `json:"hfsIters"`
+	Degrees           []int       `json:"degrees,omitempty"`
+	NumBasisFunctions []int       `json:"numBasisFunctions,omitempty"`
+	NonNegative       bool        `json:"nonNegative"`
+}
+
+// Configure applies the settings in cfg to the builder, stopping at the first one splinter rejects.
+func (builder *BSplineBuilder) Configure(cfg BuilderConfig) error {
+	if err := builder.KnotSpacing(cfg.KnotSpacing); err != nil {
+		return err
+	}
+	if err := builder.Smoothing(cfg.Smoothing); err != nil {
+		return err
+	}
+	if cfg.Alpha != 0 {
+		if err := builder.Alpha(cfg.Alpha); err != nil {
+			return err
+		}
+	}
+	if cfg.Padding != 0 {
+		if err := builder.Padding(cfg.Padding); err != nil {
+			return err
+		}
+	}
+	if len(cfg.Weights) > 0 {
+		if err := builder.Weights(cfg.Weights); err != nil {
+			return err
+		}
+	}
+	if len(cfg.Bounds) > 0 {
+		if err := builder.Bounds(cfg.Bounds); err != nil {
+			return err
+		}
+	}
+	if cfg.HfsIters > 0 {
+		if err := builder.HfsIters(cfg.HfsIters); err != nil {
+			return err
+		}
+	}
+	if len(cfg.Degrees) > 0 {
+		if err := builder.Degree(cfg.Degrees); err != nil {
+			return err
+		}
+	}
+	if len(cfg.NumBasisFunctions) > 0 {
+		if err := builder.NumBasisFunctions(cfg.NumBasisFunctions); err != nil {
+			return err
+		}
+	}
+	if cfg.NonNegative {
+		if err := builder.NonNegative(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Fit builds a spline from the samples in dt using the settings in cfg.
+func Fit(dt *DataTable, cfg BuilderConfig) (*BSpline, error) {
+	builder, err := NewBSplineBuilder(dt)
+	if err != nil {
+		return nil, err
+	}
+	defer builder.Free()
+
+	err = builder.Configure(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return builder.Build()
+}
+
+// Dataset holds the samples of one fit in FitBatch: one input row in X per response in Y.
+type Dataset struct {
+	X [][]float64
+	Y []float64
+}
+
+// check verifies that the dataset holds at least one sample and that its inputs all have the same length.
+func (d Dataset) check() error {
+	if len(d.X) != len(d.Y) {
+		return ErrLengthMismatch
+	}
+	if len(d.Y) == 0 {
+		return ErrNoSamples
+	}
+	for _, row := range d.X {
+		if len(row) != len(d.X[0]) {
+			return ErrDimensionMismatch
+		}
+	}
+	return nil
+}
+
+// FitBatch fits one spline per dataset with the settings in cfg, using up to workers goroutines (GOMAXPROCS if
+// workers <= 0). The results are index-aligned with datasets: a failed fit leaves a nil spline and its error at its
+// index without affecting the others. Each call into splinter holds a package-wide lock (see splinterMu), so the fits
+// themselves still run one at a time; the workers overlap the Go-side work around them, such as validating and copying
+// the datasets.
+func FitBatch(datasets []Dataset, cfg BuilderConfig, workers int) ([]*BSpline, []error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(datasets) {
+		workers = len(datasets)
+	}
+
+	splines := make([]*BSpline, len(datasets))
+	errs := make([]error, len(datasets))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				splines[i], errs[i] = fitDataset(datasets[i], cfg)
+			}
+		}()
+	}
+	for i := range datasets {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+	return splines, errs
+}
+
+// fitDataset fits a spline to the samples of d, freeing the intermediate table.
+func fitDataset(d Dataset, cfg BuilderConfig) (*BSpline, error) {
+	if err := d.check(); err != nil {
+		return nil, err
+	}
+
+	dt, err := newDataTableFromSamples(d.X, d.Y)
+	if err != nil {
+		return nil, err
+	}
+	defer dt.Free()
+	return Fit(dt, cfg)
+}
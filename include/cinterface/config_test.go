@@ -0,0 +1,100 @@
+package splinter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFit(t *testing.T) {
+	dt, err := newTestSpline1D(t).Resample([][]float64{linspace(0, 2, 21)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := BuilderConfig{
+		Smoothing:         SmoothingPspline,
+		Alpha:             0.01,
+		KnotSpacing:       KnotSpacingEquidistant,
+		NumBasisFunctions: []int{8},
+	}
+	bs, err := Fit(dt, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	coeffs, err := bs.GetCoefficients()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(coeffs) >= 21 {
+		t.Errorf("expected fewer coefficients than samples, got %d", len(coeffs))
+	}
+	if v, err := bs.Eval(1); err != nil || !almostEqual(v, 1, 0.05) {
+		t.Errorf("expected f(1) close to 1, got %v, %v", v, err)
+	}
+}
+
+func TestBuilderRecordsConfig(t *testing.T) {
+	dt, err := newTestSpline1D(t).Resample([][]float64{linspace(0, 2, 21)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	builder, err := NewBSplineBuilder(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := BuilderConfig{
+		KnotSpacing: KnotSpacingEquidistant,
+		Smoothing:   SmoothingIdentity,
+		Alpha:       0.5,
+		Padding:     0.1,
+		Bounds:      [][]float64{{-1, 3}},
+		HfsIters:    2,
+		Degrees:     []int{2},
+	}
+	if err := builder.Configure(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(builder.config, cfg) {
+		t.Errorf("expected %+v, got %+v", cfg, builder.config)
+	}
+}
+
+func TestFitBatch(t *testing.T) {
+	x := [][]float64{{0}, {0.5}, {1}, {1.5}, {2}}
+	line := func(slope float64) Dataset {
+		y := make([]float64, len(x))
+		for i, row := range x {
+			y[i] = slope * row[0]
+		}
+		return Dataset{X: x, Y: y}
+	}
+
+	datasets := []Dataset{line(1), line(2), {X: x, Y: []float64{1}}, line(3), {}, line(4)}
+	splines, errs := FitBatch(datasets, BuilderConfig{}, 3)
+	if len(splines) != len(datasets) || len(errs) != len(datasets) {
+		t.Fatalf("expected %d results, got %d and %d", len(datasets), len(splines), len(errs))
+	}
+
+	if errs[2] != ErrLengthMismatch || splines[2] != nil {
+		t.Errorf("expected ErrLengthMismatch at index 2, got %v", errs[2])
+	}
+	if errs[4] != ErrNoSamples || splines[4] != nil {
+		t.Errorf("expected ErrNoSamples at index 4, got %v", errs[4])
+	}
+	for i, slope := range map[int]float64{0: 1, 1: 2, 3: 3, 5: 4} {
+		if errs[i] != nil {
+			t.Errorf("index %d: unexpected error %v", i, errs[i])
+			continue
+		}
+		if v, err := splines[i].Eval(1.25); err != nil || !almostEqual(v, 1.25*slope, 1e-9) {
+			t.Errorf("index %d: expected %v, got %v (%v)", i, 1.25*slope, v, err)
+		}
+	}
+
+	if splines, errs := FitBatch(nil, BuilderConfig{}, 0); len(splines) != 0 || len(errs) != 0 {
+		t.Errorf("expected no results for no datasets")
+	}
+}
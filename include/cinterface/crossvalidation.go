@@ -0,0 +1,427 @@
+package splinter
+
+import (
+	"math"
+	"math/rand"
+)
+
+// cvFolds assigns samples to k cross-validation folds, returning the fold of each sample or -1 for samples that are
+// always used for training. Samples at the boundary of the data in any variable are never held out, so that every
+// held out sample lies inside the domain of the spline fitted to the remaining ones. The assignment is shuffled with a
+// fixed seed to make cross-validation scores reproducible.
+func cvFolds(x [][]float64, k int) ([]int, error) {
+	if len(x) == 0 {
+		return nil, ErrNoSamples
+	}
+
+	dim := len(x[0])
+	lo := append([]float64(nil), x[0]...)
+	hi := append([]float64(nil), x[0]...)
+	for _, row := range x {
+		for j, v := range row {
+			lo[j] = math.Min(lo[j], v)
+			hi[j] = math.Max(hi[j], v)
+		}
+	}
+
+	folds := make([]int, len(x))
+	interior := make([]int, 0, len(x))
+	for i, row := range x {
+		folds[i] = -1
+		boundary := false
+		for j := 0; j < dim; j++ {
+			if row[j] == lo[j] || row[j] == hi[j] {
+				boundary = true
+				break
+			}
+		}
+		if !boundary {
+			interior = append(interior, i)
+		}
+	}
+
+	if k < 2 || k > len(interior) {
+		return nil, ErrInvalidFolds
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for pos, i := range rng.Perm(len(interior)) {
+		folds[interior[i]] = pos % k
+	}
+	return folds, nil
+}
+
+// crossValidate returns the root mean squared error of k-fold cross-validation of fits with cfg on the given samples.
+// Weights in cfg are taken to refer to the given samples and are split along with them.
+func crossValidate(x [][]float64, y []float64, cfg BuilderConfig, k int) (float64, error) {
+	folds, err := cvFolds(x, k)
+	if err != nil {
+		return 0, err
+	}
+
+	sumSq := 0.0
+	count := 0
+	for fold := 0; fold < k; fold++ {
+		var trainX, testX [][]float64
+		var trainY, testY, trainW []float64
+		for i, f := range folds {
+			if f == fold {
+				testX = append(testX, x[i])
+				testY = append(testY, y[i])
+				continue
+			}
+
+			trainX = append(trainX, x[i])
+			trainY = append(trainY, y[i])
+			if len(cfg.Weights) == len(y) {
+				trainW = append(trainW, cfg.Weights[i])
+			}
+		}
+
+		foldCfg := cfg
+		foldCfg.Weights = trainW
+		predictions, err := fitAndEvalSamples(trainX, trainY, foldCfg, testX)
+		if err != nil {
+			return 0, err
+		}
+
+		for i, p := range predictions {
+			sumSq += (testY[i] - p) * (testY[i] - p)
+		}
+		count += len(predictions)
+	}
+
+	return math.Sqrt(sumSq / float64(count)), nil
+}
+
+// fitAndEvalSamples fits a spline to the training samples with cfg and evaluates it at the test inputs, freeing
+// everything it allocated.
+func fitAndEvalSamples(trainX [][]float64, trainY []float64, cfg BuilderConfig, testX [][]float64) ([]float64, error) {
+	dt, err := newDataTableFromSamples(trainX, trainY)
+	if err != nil {
+		return nil, err
+	}
+	defer dt.Free()
+
+	bs, err := Fit(dt, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer bs.Free()
+
+	return bs.evalSamples(testX)
+}
+
+// maxExhaustiveCandidates bounds the number of basis configurations AutoNumBasis tries exhaustively before it falls
+// back to a coordinate search.
+const maxExhaustiveCandidates = 64
+
+// AutoNumBasis chooses the number of basis functions per variable, between minPer and maxPer, that minimizes the
+// k-fold cross-validation error of the builder's fit, applies it to the builder and returns it.
+//
+// splinter only honors the number of basis functions with equidistant knots, so the builder is switched to
+// KnotSpacingEquidistant. Small searches try every combination; larger ones use a coordinate search, optimizing one
+// variable at a time with the others fixed until no variable changes.
+func (builder *BSplineBuilder) AutoNumBasis(minPer, maxPer int, k int) ([]int, error) {
+	if minPer < 1 || minPer > maxPer {
+		return nil, ErrInvalidRange
+	}
+	if len(builder.y) == 0 {
+		return nil, ErrNoSamples
+	}
+
+	dim := len(builder.x[0])
+	cfg := builder.config
+	cfg.KnotSpacing = KnotSpacingEquidistant
+
+	var lastErr error
+	score := func(counts []int) float64 {
+		cfg.NumBasisFunctions = counts
+		cv, err := crossValidate(builder.x, builder.y, cfg, k)
+		if err != nil {
+			// configurations splinter cannot build are skipped
+			lastErr = err
+			return math.Inf(1)
+		}
+		return cv
+	}
+
+	if _, err := cvFolds(builder.x, k); err != nil {
+		return nil, err
+	}
+
+	best := make([]int, dim)
+	for i := range best {
+		best[i] = minPer
+	}
+	bestScore := math.Inf(1)
+
+	span := maxPer - minPer + 1
+	if math.Pow(float64(span), float64(dim)) <= maxExhaustiveCandidates {
+		candidates := make([][]float64, dim)
+		for i := range candidates {
+			candidates[i] = make([]float64, span)
+			for j := range candidates[i] {
+				candidates[i][j] = float64(minPer + j)
+			}
+		}
+
+		point := make([]float64, dim)
+		for c := 0; c < gridSize(candidates); c++ {
+			gridPoint(candidates, c, point)
+			counts := make([]int, dim)
+			for i, v := range point {
+				counts[i] = int(v)
+			}
+			if s := score(counts); s < bestScore {
+				best, bestScore = counts, s
+			}
+		}
+	} else {
+		bestScore = score(best)
+		for changed := true; changed; {
+			changed = false
+			for i := 0; i < dim; i++ {
+				for n := minPer; n <= maxPer; n++ {
+					if n == best[i] {
+						continue
+					}
+					counts := append([]int(nil), best...)
+					counts[i] = n
+					if s := score(counts); s < bestScore {
+						best, bestScore = counts, s
+						changed = true
+					}
+				}
+			}
+		}
+	}
+
+	if math.IsInf(bestScore, 1) {
+		return nil, lastErr
+	}
+
+	if err := builder.KnotSpacing(KnotSpacingEquidistant); err != nil {
+		return nil, err
+	}
+	if err := builder.NumBasisFunctions(best); err != nil {
+		return nil, err
+	}
+	return best, nil
+}
+
+// AlphaSweep computes the k-fold cross-validation error (see AutoNumBasis) of the builder's fit for each of the
+// candidate smoothing parameters, in the same order, and applies the candidate with the smallest error to the builder.
+// The builder must use SmoothingIdentity or SmoothingPspline, since alpha has no effect otherwise. Every candidate
+// costs k builds.
+func (builder *BSplineBuilder) AlphaSweep(candidates []float64, k int) (cvErrors []float64, best float64, err error) {
+	if builder.config.Smoothing == SmoothingNone {
+		return nil, 0, ErrNoSmoothing
+	}
+	if len(candidates) == 0 {
+		return nil, 0, ErrInvalidCount
+	}
+	for _, alpha := range candidates {
+		if !(alpha > 0) {
+			return nil, 0, ErrInvalidAlpha
+		}
+	}
+
+	cfg := builder.config
+	cvErrors = make([]float64, len(candidates))
+	bestIndex := 0
+	for i, alpha := range candidates {
+		cfg.Alpha = alpha
+		cvErrors[i], err = crossValidate(builder.x, builder.y, cfg, k)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if cvErrors[i] < cvErrors[bestIndex] {
+			bestIndex = i
+		}
+	}
+	best = candidates[bestIndex]
+
+	err = builder.Alpha(best)
+	if err != nil {
+		return nil, 0, err
+	}
+	return cvErrors, best, nil
+}
+
+// FindElbow computes the k-fold cross-validation error (see AutoNumBasis) of the builder's fit for each of the
+// candidate numbers of basis functions per variable, returned as curve in the order of counts, and picks the elbow of
+// the error against complexity: the point beyond which more basis functions stop paying off. The chosen counts are
+// applied to the builder, which is switched to KnotSpacingEquidistant like AutoNumBasis does.
+//
+// Complexity is the total number of basis functions of a candidate. The elbow is found as in the Kneedle method:
+// with both axes scaled to [0, 1], it is the candidate lying furthest below the chord joining the least and the most
+// complex ones. If no candidate lies below the chord, as with fewer than three candidates or a curve without a bend,
+// the candidate with the smallest error is chosen instead. Unlike in AutoNumBasis, a candidate splinter cannot build
+// fails the call rather than being skipped, so that curve always covers every candidate.
+func (builder *BSplineBuilder) FindElbow(counts [][]int, k int) (chosen []int, curve []float64, err error) {
+	if len(counts) == 0 {
+		return nil, nil, ErrInvalidCount
+	}
+	if len(builder.y) == 0 {
+		return nil, nil, ErrNoSamples
+	}
+
+	dim := len(builder.x[0])
+	complexity := make([]float64, len(counts))
+	for i, c := range counts {
+		if len(c) != dim {
+			return nil, nil, ErrDimensionMismatch
+		}
+		complexity[i] = 1
+		for _, n := range c {
+			if n < 1 {
+				return nil, nil, ErrInvalidCount
+			}
+			complexity[i] *= float64(n)
+		}
+	}
+
+	cfg := builder.config
+	cfg.KnotSpacing = KnotSpacingEquidistant
+	curve = make([]float64, len(counts))
+	for i, c := range counts {
+		cfg.NumBasisFunctions = c
+		curve[i], err = crossValidate(builder.x, builder.y, cfg, k)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	chosen = append([]int(nil), counts[elbowIndex(complexity, curve)]...)
+	if err := builder.KnotSpacing(KnotSpacingEquidistant); err != nil {
+		return nil, nil, err
+	}
+	if err := builder.NumBasisFunctions(chosen); err != nil {
+		return nil, nil, err
+	}
+	return chosen, curve, nil
+}
+
+// elbowIndex returns the index of the elbow of the curve of errors against complexity, see FindElbow.
+func elbowIndex(complexity, errs []float64) int {
+	best := 0
+	lo, hi := 0, 0
+	for i := range errs {
+		if errs[i] < errs[best] {
+			best = i
+		}
+		if complexity[i] < complexity[lo] {
+			lo = i
+		}
+		if complexity[i] > complexity[hi] {
+			hi = i
+		}
+	}
+
+	minErr, maxErr := errs[best], errs[best]
+	for _, e := range errs {
+		maxErr = math.Max(maxErr, e)
+	}
+	if complexity[hi] == complexity[lo] || maxErr == minErr {
+		return best
+	}
+
+	scaleX := func(i int) float64 { return (complexity[i] - complexity[lo]) / (complexity[hi] - complexity[lo]) }
+	scaleY := func(i int) float64 { return (errs[i] - minErr) / (maxErr - minErr) }
+
+	elbow, elbowDist := best, 0.0
+	for i := range errs {
+		// height of the chord from lo to hi above the point
+		chord := scaleY(lo) + (scaleY(hi)-scaleY(lo))*scaleX(i)
+		if dist := chord - scaleY(i); dist > elbowDist {
+			elbow, elbowDist = i, dist
+		}
+	}
+	return elbow
+}
+
+// LooCV returns the root mean squared leave-one-out cross-validation error of the spline's fit on the samples in dt.
+//
+// splinter does not expose the hat matrix H of its fits, but they are linear smoothers, so when dt holds the samples
+// the spline was built from, the diagonal of H is computed on the Go side from the spline's knots and the builder's
+// smoothing, alpha and weights, and the leave-one-out residuals follow without refitting as r_i / (1 - H_ii). This
+// costs one solve per sample with a matrix quadratic in the number of basis functions.
+//
+// Otherwise, or when the shortcut does not apply (interpolating fits, where H_ii = 1), every sample is left out in
+// turn and the data refitted with the spline's settings, which is much slower. Refits skip samples on the boundary of
+// the data, as leaving them out would shrink the domain (see AutoNumBasis). Only splines returned by a builder can
+// be cross-validated.
+func (bs *BSpline) LooCV(dt *DataTable) (float64, error) {
+	if dt == nil {
+		return 0, ErrInvalidNil
+	}
+	if bs.config == nil {
+		return 0, ErrNoTrainingData
+	}
+	if len(dt.y) == 0 {
+		return 0, ErrNoSamples
+	}
+
+	if bs.builtFrom(dt) {
+		loo, ok, err := bs.looShortcut()
+		if err != nil || ok {
+			return loo, err
+		}
+	}
+
+	folds, err := cvFolds(dt.x, 2)
+	if err != nil {
+		return 0, err
+	}
+	interior := 0
+	for _, f := range folds {
+		if f >= 0 {
+			interior++
+		}
+	}
+	return crossValidate(dt.x, dt.y, *bs.config, interior)
+}
+
+// builtFrom reports whether dt holds exactly the samples the spline was built from.
+func (bs *BSpline) builtFrom(dt *DataTable) bool {
+	if len(dt.y) != len(bs.y) {
+		return false
+	}
+	for i := range dt.y {
+		if dt.y[i] != bs.y[i] || !equalX(dt.x[i], bs.x[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// looShortcut computes the leave-one-out error of the spline on its own samples from the diagonal of the hat matrix.
+// ok is false if the hat matrix cannot be formed or has unit diagonal entries.
+func (bs *BSpline) looShortcut() (loo float64, ok bool, err error) {
+	basis, err := bs.basis()
+	if err != nil {
+		return 0, false, err
+	}
+	l, ok := cholesky(normalMatrix(basis, bs.x, *bs.config))
+	if !ok {
+		return 0, false, nil
+	}
+
+	predictions, err := bs.evalSamples(bs.x)
+	if err != nil {
+		return 0, false, err
+	}
+
+	sumSq := 0.0
+	hat := hatDiagonal(basis, l, bs.x, *bs.config)
+	for s, h := range hat {
+		if 1-h < 1e-8 {
+			return 0, false, nil
+		}
+		r := (bs.y[s] - predictions[s]) / (1 - h)
+		sumSq += r * r
+	}
+	return math.Sqrt(sumSq / float64(len(bs.x))), true, nil
+}
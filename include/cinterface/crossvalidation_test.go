@@ -0,0 +1,186 @@
+package splinter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCrossValidate(t *testing.T) {
+	dt := noisyTable(t)
+
+	smooth, err := crossValidate(dt.x, dt.y, BuilderConfig{Smoothing: SmoothingPspline, Alpha: 0.1}, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	interpolating, err := crossValidate(dt.x, dt.y, BuilderConfig{}, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !(smooth > 0 && smooth < interpolating) {
+		t.Errorf("expected smoothing to generalize better than interpolation, got %v vs %v", smooth, interpolating)
+	}
+
+	if _, err := crossValidate(dt.x, dt.y, BuilderConfig{}, 1); err != ErrInvalidFolds {
+		t.Errorf("expected ErrInvalidFolds, got %v", err)
+	}
+}
+
+func TestAutoNumBasis(t *testing.T) {
+	builder, err := NewBSplineBuilder(noisyTable(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counts, err := builder.AutoNumBasis(4, 20, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(counts) != 1 || counts[0] < 4 || counts[0] > 20 {
+		t.Fatalf("unexpected basis counts %v", counts)
+	}
+	if builder.config.KnotSpacing != KnotSpacingEquidistant || builder.config.NumBasisFunctions[0] != counts[0] {
+		t.Errorf("expected the chosen counts to be applied, got %+v", builder.config)
+	}
+
+	if _, err := builder.Build(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := builder.AutoNumBasis(5, 4, 5); err != ErrInvalidRange {
+		t.Errorf("expected ErrInvalidRange, got %v", err)
+	}
+}
+
+func TestAlphaSweep(t *testing.T) {
+	builder, err := NewBSplineBuilder(noisyTable(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := builder.AlphaSweep([]float64{0.1}, 5); err != ErrNoSmoothing {
+		t.Errorf("expected ErrNoSmoothing, got %v", err)
+	}
+
+	if err := builder.Smoothing(SmoothingPspline); err != nil {
+		t.Fatal(err)
+	}
+	candidates := []float64{1e-6, 1e-3, 0.1, 10, 1000}
+	cvErrors, best, err := builder.AlphaSweep(candidates, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cvErrors) != len(candidates) {
+		t.Fatalf("expected %d errors, got %d", len(candidates), len(cvErrors))
+	}
+	bestError := math.Inf(1)
+	for i, e := range cvErrors {
+		if candidates[i] == best {
+			bestError = e
+		}
+	}
+	for i, e := range cvErrors {
+		if e < bestError {
+			t.Errorf("candidate %v has a smaller error than the selected %v", candidates[i], best)
+		}
+	}
+	// neither nearly interpolating the noise nor flattening the sine generalizes best
+	if best == candidates[0] || best == candidates[len(candidates)-1] {
+		t.Errorf("expected an intermediate alpha, got %v with errors %v", best, cvErrors)
+	}
+	if builder.config.Alpha != best {
+		t.Errorf("expected alpha %v to be applied, got %v", best, builder.config.Alpha)
+	}
+
+	if _, _, err := builder.AlphaSweep([]float64{0.1, 0}, 5); err != ErrInvalidAlpha {
+		t.Errorf("expected ErrInvalidAlpha, got %v", err)
+	}
+}
+
+func TestFindElbow(t *testing.T) {
+	builder, err := NewBSplineBuilder(noisyTable(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counts := [][]int{{6}, {8}, {10}, {14}, {18}}
+	chosen, curve, err := builder.FindElbow(counts, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(curve) != len(counts) {
+		t.Fatalf("expected %d errors, got %d", len(counts), len(curve))
+	}
+	if len(chosen) != 1 || builder.config.KnotSpacing != KnotSpacingEquidistant ||
+		builder.config.NumBasisFunctions[0] != chosen[0] {
+		t.Errorf("expected the chosen counts to be applied, got %v and %+v", chosen, builder.config)
+	}
+
+	if _, _, err := builder.FindElbow([][]int{{4, 4}}, 5); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, _, err := builder.FindElbow(nil, 5); err != ErrInvalidCount {
+		t.Errorf("expected ErrInvalidCount, got %v", err)
+	}
+}
+
+func TestElbowIndex(t *testing.T) {
+	// the error drops steeply up to complexity 8 and flattens after
+	complexity := []float64{16, 4, 8, 32, 6}
+	errs := []float64{0.09, 1, 0.1, 0.08, 0.5}
+	if i := elbowIndex(complexity, errs); i != 2 {
+		t.Errorf("expected the elbow at index 2, got %d", i)
+	}
+
+	// a straight line has no elbow, so the smallest error wins
+	if i := elbowIndex([]float64{1, 2, 3}, []float64{3, 2, 1}); i != 2 {
+		t.Errorf("expected index 2, got %d", i)
+	}
+}
+
+func TestLooCV(t *testing.T) {
+	// fixed bounds and basis size keep the knots of the leave-one-out refits equal to the full fit's
+	dt := noisyTable(t)
+	cfg := BuilderConfig{
+		Smoothing:         SmoothingPspline,
+		Alpha:             0.1,
+		KnotSpacing:       KnotSpacingEquidistant,
+		NumBasisFunctions: []int{10},
+		Bounds:            [][]float64{{0, 3}},
+	}
+	bs, err := Fit(dt, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loo, err := bs.LooCV(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sumSq := 0.0
+	for i := range dt.y {
+		x := append(append([][]float64{}, dt.x[:i]...), dt.x[i+1:]...)
+		y := append(append([]float64{}, dt.y[:i]...), dt.y[i+1:]...)
+		predictions, err := fitAndEvalSamples(x, y, cfg, dt.x[i:i+1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sumSq += (dt.y[i] - predictions[0]) * (dt.y[i] - predictions[0])
+	}
+	expected := math.Sqrt(sumSq / float64(len(dt.y)))
+	if !almostEqual(loo, expected, 1e-6) {
+		t.Errorf("expected the shortcut to match explicit refits, %v vs %v", loo, expected)
+	}
+
+	// interpolating fits fall back to refits
+	interpolant, err := Fit(dt, BuilderConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	loo, err = interpolant.LooCV(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loo <= 0 {
+		t.Errorf("expected a positive error, got %v", loo)
+	}
+}
@@ -0,0 +1,81 @@
+package splinter
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+)
+
+// NewDataTableFromCSV creates a table from the CSV file at path, one sample per row, with column yCol holding the
+// response and the other columns, in order, the inputs. A first row that is not numeric is taken to be a header and
+// skipped. Every row must have the same number of fields as the first; a row that does not, or has a field that is not
+// a number, fails the whole read with an ErrMalformedLine. A yCol outside the columns of the file gives
+// ErrInvalidColumn. The columns are added with AddColumns, so a sample whose inputs repeat an earlier one is
+// discarded.
+func NewDataTableFromCSV(path string, yCol int) (*DataTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var columns [][]float64
+	reader := csv.NewReader(f)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrMalformedLine{Line: line, Err: err}
+		}
+		if line == 1 {
+			if yCol < 0 || yCol >= len(record) {
+				return nil, ErrInvalidColumn
+			}
+			if len(record) < 2 {
+				return nil, ErrMalformedLine{Line: line, Err: errors.New("no input columns")}
+			}
+		}
+
+		row := make([]float64, len(record))
+		for i, field := range record {
+			row[i], err = strconv.ParseFloat(field, 64)
+			if err != nil {
+				break
+			}
+		}
+		if err != nil {
+			if line == 1 {
+				continue
+			}
+			return nil, ErrMalformedLine{Line: line, Err: err}
+		}
+
+		// the inputs in file order, followed by the response
+		if columns == nil {
+			columns = make([][]float64, len(row))
+		}
+		j := 0
+		for i, v := range row {
+			if i != yCol {
+				columns[j] = append(columns[j], v)
+				j++
+			}
+		}
+		columns[len(row)-1] = append(columns[len(row)-1], row[yCol])
+	}
+
+	dt, err := NewDataTable()
+	if err != nil {
+		return nil, err
+	}
+	err = dt.AddColumns(columns...)
+	if err != nil {
+		dt.Free()
+		return nil, err
+	}
+	return dt, nil
+}
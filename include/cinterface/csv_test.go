@@ -0,0 +1,58 @@
+package splinter
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestNewDataTableFromCSV(t *testing.T) {
+	dir, err := ioutil.TempDir("", "splinter-csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "samples.csv")
+	write := func(content string) {
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the response is in the middle column, and the header is skipped
+	write("a,y,b\n1,3,0\n0,-1,0.5\n")
+	dt, err := NewDataTableFromCSV(path, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, y := dt.Samples()
+	if !reflect.DeepEqual(x, [][]float64{{0, 0.5}, {1, 0}}) || !reflect.DeepEqual(y, []float64{-1, 3}) {
+		t.Errorf("unexpected samples %v %v", x, y)
+	}
+
+	malformed := []struct {
+		content string
+		line    int
+	}{
+		{"1,2\n3,4,5\n", 2},
+		{"x,y\n1,2\n3,abc\n", 3},
+		{"1\n", 1},
+	}
+	for _, m := range malformed {
+		write(m.content)
+		_, err := NewDataTableFromCSV(path, 0)
+		if e, ok := err.(ErrMalformedLine); !ok || e.Line != m.line {
+			t.Errorf("%q: expected an error on line %d, got %v", m.content, m.line, err)
+		}
+	}
+
+	write("1,2\n")
+	if _, err := NewDataTableFromCSV(path, 2); err != ErrInvalidColumn {
+		t.Errorf("expected ErrInvalidColumn, got %v", err)
+	}
+	if _, err := NewDataTableFromCSV(filepath.Join(dir, "missing.csv"), 0); !os.IsNotExist(err) {
+		t.Errorf("expected a not-exist error, got %v", err)
+	}
+}
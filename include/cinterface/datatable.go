@@ -0,0 +1,299 @@
+package splinter
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// lessX orders sample inputs lexicographically, which is the order splinter keeps its samples in.
+func lessX(a, b []float64) bool {
+	for i := range a {
+		if a[i] < b[i] {
+			return true
+		} else if a[i] > b[i] {
+			return false
+		}
+	}
+	return false
+}
+
+func equalX(a, b []float64) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// mirrorColumns records samples given in the AddColumns layout. Like splinter, the samples are kept sorted by their
+// inputs, and a sample whose inputs are already in the table is discarded.
+func (dt *DataTable) mirrorColumns(columns [][]float64) {
+	dim := len(columns) - 1
+	n := len(columns[dim])
+
+	type sample struct {
+		x []float64
+		y float64
+	}
+	samples := make([]sample, 0, len(dt.y)+n)
+	for i := range dt.y {
+		samples = append(samples, sample{dt.x[i], dt.y[i]})
+	}
+	for i := 0; i < n; i++ {
+		x := make([]float64, dim)
+		for j := range x {
+			x[j] = columns[j][i]
+		}
+		samples = append(samples, sample{x, columns[dim][i]})
+	}
+
+	// stable, so the first of several duplicates is the one kept
+	sort.SliceStable(samples, func(i, j int) bool { return lessX(samples[i].x, samples[j].x) })
+
+	x := make([][]float64, 0, len(samples))
+	y := make([]float64, 0, len(samples))
+	for _, s := range samples {
+		if len(x) > 0 && equalX(x[len(x)-1], s.x) {
+			continue
+		}
+		x = append(x, s.x)
+		y = append(y, s.y)
+	}
+	dt.x = x
+	dt.y = y
+}
+
+// AddRow adds a single sample given as its inputs followed by the response, {x1, ..., xn, y}. See AddRows.
+func (dt *DataTable) AddRow(sample []float64) error {
+	return dt.AddRows([][]float64{sample})
+}
+
+// AddRows adds samples given one per row as their inputs followed by the response, {x1, ..., xn, y}, the transpose of
+// the AddColumns layout. Every row must have the same length, and at least two values; the first row of an empty table
+// sets the width, and for a table with samples it is the number of variables plus one. Otherwise ErrLengthMismatch is
+// returned and nothing is added. As with AddColumns, a sample whose inputs are already in the table is discarded.
+func (dt *DataTable) AddRows(samples [][]float64) error {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	width := len(samples[0])
+	if len(dt.y) > 0 {
+		width = dt.numVariables() + 1
+	}
+	if width < 2 {
+		return ErrLengthMismatch
+	}
+	for _, row := range samples {
+		if len(row) != width {
+			return ErrLengthMismatch
+		}
+	}
+
+	columns := make([][]float64, width)
+	for j := range columns {
+		columns[j] = make([]float64, len(samples))
+		for i, row := range samples {
+			columns[j][i] = row[j]
+		}
+	}
+	return dt.AddColumns(columns...)
+}
+
+// Samples returns a copy of the samples in the table, as one input row per sample and the corresponding responses.
+// The samples are in the order splinter stores them (sorted by input, without duplicates), which is also the order
+// BSplineBuilder.Weights refers to.
+func (dt *DataTable) Samples() (x [][]float64, y []float64) {
+	return copySamples(dt.x, dt.y)
+}
+
+func copySamples(x [][]float64, y []float64) ([][]float64, []float64) {
+	xCopy := make([][]float64, len(x))
+	for i, row := range x {
+		xCopy[i] = append([]float64(nil), row...)
+	}
+	return xCopy, append([]float64(nil), y...)
+}
+
+// FilterBounds drops the samples whose inputs fall outside bounds, given as one [min, max] pair per variable like
+// BSplineBuilder.Bounds, and returns the number of samples removed. The table is rebuilt in place, so builders
+// created from it earlier are not affected.
+func (dt *DataTable) FilterBounds(bounds [][]float64) (removed int, err error) {
+	if len(bounds) != dt.numVariables() {
+		return 0, ErrDimensionMismatch
+	}
+	for _, b := range bounds {
+		if len(b) != 2 {
+			return 0, ErrInvalidBounds
+		}
+	}
+
+	x := make([][]float64, 0, len(dt.x))
+	y := make([]float64, 0, len(dt.y))
+	for i, row := range dt.x {
+		if insideBounds(row, bounds) {
+			x = append(x, row)
+			y = append(y, dt.y[i])
+		}
+	}
+
+	removed = len(dt.y) - len(y)
+	if removed == 0 {
+		return 0, nil
+	}
+
+	err = dt.replaceSamples(x, y)
+	if err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
+
+func insideBounds(x []float64, bounds [][]float64) bool {
+	for i, b := range bounds {
+		if x[i] < b[0] || x[i] > b[1] {
+			return false
+		}
+	}
+	return true
+}
+
+// nearestSample returns the index of the sample whose inputs are closest to x in Euclidean distance, the first one in
+// splinter order on ties.
+func (dt *DataTable) nearestSample(x []float64) (int, error) {
+	if len(dt.y) == 0 {
+		return 0, ErrNoSamples
+	}
+	if len(x) != len(dt.x[0]) {
+		return 0, ErrDimensionMismatch
+	}
+
+	best, bestDist := 0, math.Inf(1)
+	for i, row := range dt.x {
+		dist := 0.0
+		for j, v := range row {
+			dist += (v - x[j]) * (v - x[j])
+		}
+		if dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	return best, nil
+}
+
+// Standardize transforms every input variable of the table in place to zero mean and unit (population) standard
+// deviation, leaving the responses unchanged, and returns the means and standard deviations used. Query points are
+// mapped to the standardized inputs with (x[i] - means[i]) / stddevs[i]. Nothing is changed if some variable is
+// constant, since it cannot be scaled. Like FilterBounds, the table is rebuilt, so earlier builders are not affected.
+func (dt *DataTable) Standardize() (means, stddevs []float64, err error) {
+	if len(dt.y) == 0 {
+		return nil, nil, ErrNoSamples
+	}
+
+	dim := dt.numVariables()
+	n := float64(len(dt.y))
+	means = make([]float64, dim)
+	stddevs = make([]float64, dim)
+	for _, row := range dt.x {
+		for j, v := range row {
+			means[j] += v / n
+		}
+	}
+	for _, row := range dt.x {
+		for j, v := range row {
+			stddevs[j] += (v - means[j]) * (v - means[j]) / n
+		}
+	}
+	for j := range stddevs {
+		stddevs[j] = math.Sqrt(stddevs[j])
+		if stddevs[j] == 0 {
+			return nil, nil, ErrZeroVariance
+		}
+	}
+
+	// an increasing affine map per variable keeps the samples in splinter order
+	x := make([][]float64, len(dt.x))
+	for i, row := range dt.x {
+		x[i] = make([]float64, dim)
+		for j, v := range row {
+			x[i][j] = (v - means[j]) / stddevs[j]
+		}
+	}
+
+	err = dt.replaceSamples(x, dt.y)
+	if err != nil {
+		return nil, nil, err
+	}
+	return means, stddevs, nil
+}
+
+// Split partitions the samples of the table at random into a training table holding a fraction trainFrac of them
+// (rounded to the nearest count) and a test table holding the rest. The shuffle is seeded with seed, so a split can be
+// reproduced. The table itself is left unchanged. Both tables must get at least one sample: ErrNoSamples is returned
+// for an empty table, and ErrInvalidFraction for a fraction that rounds to none or all of the samples.
+func (dt *DataTable) Split(trainFrac float64, seed int64) (train, test *DataTable, err error) {
+	if !(trainFrac > 0 && trainFrac < 1) {
+		return nil, nil, ErrInvalidFraction
+	}
+
+	if len(dt.y) == 0 {
+		return nil, nil, ErrNoSamples
+	}
+	numTrain := int(math.Floor(trainFrac*float64(len(dt.y)) + 0.5))
+	if numTrain == 0 || numTrain == len(dt.y) {
+		return nil, nil, ErrInvalidFraction
+	}
+
+	perm := rand.New(rand.NewSource(seed)).Perm(len(dt.y))
+
+	subset := func(indices []int) (*DataTable, error) {
+		// keep the samples in splinter order
+		sort.Ints(indices)
+		x := make([][]float64, len(indices))
+		y := make([]float64, len(indices))
+		for k, i := range indices {
+			x[k], y[k] = dt.x[i], dt.y[i]
+		}
+		return newDataTableFromSamples(x, y)
+	}
+
+	train, err = subset(perm[:numTrain])
+	if err != nil {
+		return nil, nil, err
+	}
+	test, err = subset(perm[numTrain:])
+	if err != nil {
+		train.Free()
+		return nil, nil, err
+	}
+	return train, test, nil
+}
+
+// newDataTableFromSamples creates a table holding the given samples, one input row per response.
+func newDataTableFromSamples(x [][]float64, y []float64) (*DataTable, error) {
+	dt, err := NewDataTable()
+	if err != nil {
+		return nil, err
+	}
+	if len(y) == 0 {
+		return dt, nil
+	}
+
+	columns := make([][]float64, len(x[0])+1)
+	for j := range x[0] {
+		columns[j] = make([]float64, len(y))
+		for i, row := range x {
+			columns[j][i] = row[j]
+		}
+	}
+	columns[len(x[0])] = y
+
+	err = dt.AddColumns(columns...)
+	if err != nil {
+		dt.Free()
+		return nil, err
+	}
+	return dt, nil
+}
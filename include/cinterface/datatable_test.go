@@ -0,0 +1,200 @@
+package splinter
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestDataTableSamples(t *testing.T) {
+	dt, err := NewDataTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dt.AddColumns([]float64{2, 0, 1}, []float64{1, 1, 1}, []float64{20, 0, 10}); err != nil {
+		t.Fatal(err)
+	}
+	// the duplicate of (0, 1) is discarded, like splinter does
+	if err := dt.AddColumns([]float64{0, 0.5}, []float64{1, 1}, []float64{99, 5}); err != nil {
+		t.Fatal(err)
+	}
+
+	x, y := dt.Samples()
+	expectedX := [][]float64{{0, 1}, {0.5, 1}, {1, 1}, {2, 1}}
+	expectedY := []float64{0, 5, 10, 20}
+	if !reflect.DeepEqual(x, expectedX) || !reflect.DeepEqual(y, expectedY) {
+		t.Errorf("expected %v %v, got %v %v", expectedX, expectedY, x, y)
+	}
+}
+
+func TestAddRows(t *testing.T) {
+	dt, err := NewDataTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dt.AddRows([][]float64{{1, 0, 3}, {0, 0.5, -1}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dt.AddRow([]float64{0.5, 0.5, 2}); err != nil {
+		t.Fatal(err)
+	}
+	x, y := dt.Samples()
+	expectedX := [][]float64{{0, 0.5}, {0.5, 0.5}, {1, 0}}
+	expectedY := []float64{-1, 2, 3}
+	if !reflect.DeepEqual(x, expectedX) || !reflect.DeepEqual(y, expectedY) {
+		t.Errorf("expected %v %v, got %v %v", expectedX, expectedY, x, y)
+	}
+
+	// the width is fixed by the samples already in the table
+	if err := dt.AddRow([]float64{1, 2}); err != ErrLengthMismatch {
+		t.Errorf("expected ErrLengthMismatch, got %v", err)
+	}
+	if err := dt.AddRows([][]float64{{2, 2, 2}, {3, 3}}); err != ErrLengthMismatch {
+		t.Errorf("expected ErrLengthMismatch, got %v", err)
+	}
+	if len(dt.y) != 3 {
+		t.Errorf("rejected rows should not be added, got %d samples", len(dt.y))
+	}
+
+	empty, err := NewDataTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := empty.AddRow([]float64{1}); err != ErrLengthMismatch {
+		t.Errorf("expected ErrLengthMismatch for a row without inputs, got %v", err)
+	}
+}
+
+func TestFilterBounds(t *testing.T) {
+	bs := newTestSpline2D(t)
+	dt, err := bs.Resample([][]float64{linspace(0, 1, 11), linspace(0, 1, 11)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := dt.FilterBounds([][]float64{{0, 0.5}, {0.2, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 6 of 11 values kept in the first dimension, 9 of 11 in the second
+	if removed != 121-6*9 {
+		t.Errorf("expected %d samples removed, got %d", 121-6*9, removed)
+	}
+
+	x, _ := dt.Samples()
+	for _, row := range x {
+		if row[0] > 0.5 || row[1] < 0.2 {
+			t.Errorf("sample %v should have been removed", row)
+		}
+	}
+
+	// the rebuilt table is still usable by splinter
+	builder, err := NewBSplineBuilder(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	refit, err := builder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := refit.Eval(0.25, 0.5); err != nil || !almostEqual(v, bilinearish([]float64{0.25, 0.5}), 1e-9) {
+		t.Errorf("unexpected refit value %v, %v", v, err)
+	}
+
+	if _, err := dt.FilterBounds([][]float64{{0, 1}}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := dt.FilterBounds([][]float64{{0, 1}, {0}}); err != ErrInvalidBounds {
+		t.Errorf("expected ErrInvalidBounds, got %v", err)
+	}
+}
+
+func TestStandardize(t *testing.T) {
+	bs := newTestSpline2D(t)
+	dt, err := bs.Resample([][]float64{linspace(0, 1, 5), linspace(2, 6, 3)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, yBefore := dt.Samples()
+
+	means, stddevs, err := dt.Standardize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual(means[0], 0.5, 1e-12) || !almostEqual(means[1], 4, 1e-12) {
+		t.Errorf("unexpected means %v", means)
+	}
+	if !almostEqual(stddevs[0], math.Sqrt(0.125), 1e-12) || !almostEqual(stddevs[1], math.Sqrt(8.0/3), 1e-12) {
+		t.Errorf("unexpected standard deviations %v", stddevs)
+	}
+
+	x, y := dt.Samples()
+	if !reflect.DeepEqual(y, yBefore) {
+		t.Errorf("responses should not change")
+	}
+	if !almostEqual(x[0][0], -0.5/math.Sqrt(0.125), 1e-12) {
+		t.Errorf("unexpected first sample %v", x[0])
+	}
+
+	constant, err := NewDataTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := constant.AddColumns([]float64{1, 2}, []float64{3, 3}, []float64{0, 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := constant.Standardize(); err != ErrZeroVariance {
+		t.Errorf("expected ErrZeroVariance, got %v", err)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	dt := noisyTable(t)
+
+	train, test, err := dt.Split(0.75, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(train.y) != 30 || len(test.y) != 10 {
+		t.Fatalf("expected 30 training and 10 test samples, got %d and %d", len(train.y), len(test.y))
+	}
+
+	// together the two tables hold every sample once
+	seen := map[float64]int{}
+	for _, table := range []*DataTable{train, test} {
+		x, _ := table.Samples()
+		for _, row := range x {
+			seen[row[0]]++
+		}
+	}
+	for _, row := range dt.x {
+		if seen[row[0]] != 1 {
+			t.Errorf("sample %v appears %d times", row, seen[row[0]])
+		}
+	}
+
+	again, _, err := dt.Split(0.75, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(again.x, train.x) {
+		t.Errorf("expected the same seed to give the same split")
+	}
+
+	// 0.01 and 0.99 of 40 samples round to none and all of them
+	for _, frac := range []float64{0, 1, math.NaN(), 0.01, 0.99} {
+		if _, _, err := dt.Split(frac, 1); err != ErrInvalidFraction {
+			t.Errorf("fraction %v: expected ErrInvalidFraction, got %v", frac, err)
+		}
+	}
+
+	empty, err := NewDataTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := empty.Split(0.5, 1); err != ErrNoSamples {
+		t.Errorf("expected ErrNoSamples, got %v", err)
+	}
+}
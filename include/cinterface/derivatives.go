@@ -0,0 +1,216 @@
+package splinter
+
+import (
+	"math"
+)
+
+// DirectionalSecondDerivative evaluates the second derivative of the spline along dir at the given point, computed
+// from the Hessian H as dirᵀ H dir. dir is normalized before use, so it only needs to be non-zero.
+func (bs *BSpline) DirectionalSecondDerivative(dir []float64, vals ...float64) (float64, error) {
+	n, err := bs.NumVariables()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(dir) != n || len(vals) != n {
+		return 0, ErrDimensionMismatch
+	}
+
+	norm := 0.0
+	for _, d := range dir {
+		norm += d * d
+	}
+	norm = math.Sqrt(norm)
+	if norm == 0 {
+		return 0, ErrZeroDirection
+	}
+
+	hessian, err := bs.EvalHessian(vals...)
+	if err != nil {
+		return 0, err
+	}
+
+	res := 0.0
+	for i := range hessian {
+		for j := range hessian[i] {
+			res += dir[i] * hessian[i][j] * dir[j]
+		}
+	}
+	return res / (norm * norm), nil
+}
+
+// Roughness returns the standard smoothness penalty of the spline, the integral over the domain of the sum of its
+// squared unmixed second derivatives. The integral is computed with a tensor product Gauss-Legendre rule using
+// degree+1 points per knot span and variable, evaluating the Hessian at every point, which is exact up to rounding but
+// grows exponentially in the number of variables.
+func (bs *BSpline) Roughness() (float64, error) {
+	axes, weights, err := bs.quadratureGrid(0)
+	if err != nil {
+		return 0, err
+	}
+
+	n := len(axes)
+	size := gridSize(axes)
+	flat := make([]float64, size*n)
+	for k := 0; k < size; k++ {
+		gridPoint(axes, k, flat[k*n:(k+1)*n])
+	}
+
+	hessians, err := bs.evalHessianRowMajor(flat, size)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0.0
+	w := make([]float64, n)
+	for k := 0; k < size; k++ {
+		gridPoint(weights, k, w)
+		weight := 1.0
+		for _, wi := range w {
+			weight *= wi
+		}
+
+		hessian := hessians[k*n*n : (k+1)*n*n]
+		for i := 0; i < n; i++ {
+			total += weight * hessian[i*n+i] * hessian[i*n+i]
+		}
+	}
+	return total, nil
+}
+
+// LipschitzEstimate returns the largest gradient norm of the spline over a grid of gridPerDim evenly spaced points per
+// variable spanning its domain. Being a maximum over samples, it is a lower bound on the Lipschitz constant, not a
+// certified upper bound; a finer grid tightens it.
+func (bs *BSpline) LipschitzEstimate(gridPerDim int) (float64, error) {
+	flat, size, err := bs.domainGrid(gridPerDim)
+	if err != nil {
+		return 0, err
+	}
+	n := len(flat) / size
+
+	gradients, err := bs.evalJacobianRowMajor(flat, size)
+	if err != nil {
+		return 0, err
+	}
+
+	maxNorm := 0.0
+	for k := 0; k < size; k++ {
+		normSq := 0.0
+		for _, g := range gradients[k*n : (k+1)*n] {
+			normSq += g * g
+		}
+		maxNorm = math.Max(maxNorm, math.Sqrt(normSq))
+	}
+	return maxNorm, nil
+}
+
+// GradientStats summarizes how steep the spline is, returning the mean and the largest gradient norm over a grid of
+// gridPerDim evenly spaced points per variable spanning its domain, and the grid point where the largest norm occurs
+// (the first such point in EvalGrid order). Like LipschitzEstimate, max is a lower bound on the true maximum.
+func (bs *BSpline) GradientStats(gridPerDim int) (mean, max float64, at []float64, err error) {
+	flat, size, err := bs.domainGrid(gridPerDim)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	n := len(flat) / size
+
+	gradients, err := bs.evalJacobianRowMajor(flat, size)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	sum := 0.0
+	for k := 0; k < size; k++ {
+		normSq := 0.0
+		for _, g := range gradients[k*n : (k+1)*n] {
+			normSq += g * g
+		}
+		norm := math.Sqrt(normSq)
+		sum += norm
+		if at == nil || norm > max {
+			max = norm
+			at = flat[k*n : (k+1)*n : (k+1)*n]
+		}
+	}
+	return sum / float64(size), max, append([]float64(nil), at...), nil
+}
+
+// EvalValueGradHessBatch evaluates the spline, its gradient and its Hessian at each of the given points, as needed for
+// Newton steps from many candidates at once, returning them aligned with points. Each of the three is computed in a
+// single call into splinter for the whole batch. Hessians are numVariables x numVariables, like EvalHessian.
+func (bs *BSpline) EvalValueGradHessBatch(points [][]float64) (values []float64, grads [][]float64, hessians [][][]float64, err error) {
+	n, err := bs.NumVariables()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	size := len(points)
+	flat := make([]float64, 0, size*n)
+	for _, point := range points {
+		if len(point) != n {
+			return nil, nil, nil, ErrDimensionMismatch
+		}
+		flat = append(flat, point...)
+	}
+
+	values, err = bs.evalRowMajor(flat, size)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	jacobians, err := bs.evalJacobianRowMajor(flat, size)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	flatHessians, err := bs.evalHessianRowMajor(flat, size)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	grads = make([][]float64, size)
+	hessians = make([][][]float64, size)
+	for k := range points {
+		grads[k] = jacobians[k*n : (k+1)*n : (k+1)*n]
+		hessians[k] = make([][]float64, n)
+		for i := range hessians[k] {
+			start := (k*n + i) * n
+			hessians[k][i] = flatHessians[start : start+n : start+n]
+		}
+	}
+	return values, grads, hessians, nil
+}
+
+// IsLinear reports whether the spline is linear (affine) to within tol, that is whether the Frobenius norm of its
+// Hessian stays at most tol, and returns the largest norm found. The Hessian is evaluated at degree+1 Gauss points
+// per knot span and variable. On each span it is a polynomial of at most that degree in each variable, so if it
+// vanishes at those points it vanishes on the whole span: with tol 0 the check is exact. With a positive tol it
+// compares the largest norm at those points, which may be below the largest over the domain, against tol.
+func (bs *BSpline) IsLinear(tol float64) (linear bool, maxCurvature float64, err error) {
+	if !(tol >= 0) {
+		return false, 0, ErrInvalidTolerance
+	}
+
+	axes, _, err := bs.quadratureGrid(0)
+	if err != nil {
+		return false, 0, err
+	}
+
+	n := len(axes)
+	size := gridSize(axes)
+	flat := make([]float64, size*n)
+	for k := 0; k < size; k++ {
+		gridPoint(axes, k, flat[k*n:(k+1)*n])
+	}
+
+	hessians, err := bs.evalHessianRowMajor(flat, size)
+	if err != nil {
+		return false, 0, err
+	}
+	for k := 0; k < size; k++ {
+		normSq := 0.0
+		for _, h := range hessians[k*n*n : (k+1)*n*n] {
+			normSq += h * h
+		}
+		maxCurvature = math.Max(maxCurvature, math.Sqrt(normSq))
+	}
+	return maxCurvature <= tol, maxCurvature, nil
+}
@@ -0,0 +1,181 @@
+package splinter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEvalHessian(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	hessian, err := bs.EvalHessian(0.3, 0.6)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// d²/dx0² = 2, d²/dx0dx1 = 1, d²/dx1² = 0
+	expected := [][]float64{{2, 1}, {1, 0}}
+	for i := range expected {
+		for j := range expected[i] {
+			if !almostEqual(hessian[i][j], expected[i][j], 1e-8) {
+				t.Errorf("H[%d][%d]: expected %v, got %v", i, j, expected[i][j], hessian[i][j])
+			}
+		}
+	}
+
+	if _, err := bs.EvalHessian(0.3); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
+func TestDirectionalSecondDerivative(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	// along (1, 1)/sqrt(2): (2 + 1 + 1 + 0) / 2 = 2
+	d2, err := bs.DirectionalSecondDerivative([]float64{3, 3}, 0.3, 0.6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual(d2, 2, 1e-8) {
+		t.Errorf("expected 2, got %v", d2)
+	}
+
+	if _, err := bs.DirectionalSecondDerivative([]float64{0, 0}, 0.3, 0.6); err != ErrZeroDirection {
+		t.Errorf("expected ErrZeroDirection, got %v", err)
+	}
+	if _, err := bs.DirectionalSecondDerivative([]float64{1}, 0.3, 0.6); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
+func TestRoughness(t *testing.T) {
+	// the second derivative of x^2 is 2, integrated squared over [0, 2]
+	roughness, err := newTestSpline1D(t).Roughness()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual(roughness, 8, 1e-6) {
+		t.Errorf("expected 8, got %v", roughness)
+	}
+
+	// only x0^2 contributes to x0^2 + x0*x1 over the unit square
+	roughness, err = newTestSpline2D(t).Roughness()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual(roughness, 4, 1e-6) {
+		t.Errorf("expected 4, got %v", roughness)
+	}
+}
+
+func TestLipschitzEstimate(t *testing.T) {
+	// |d/dx x^2| peaks at the right end of [0, 2]
+	lipschitz, err := newTestSpline1D(t).LipschitzEstimate(11)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual(lipschitz, 4, 1e-6) {
+		t.Errorf("expected 4, got %v", lipschitz)
+	}
+
+	// the gradient (2x0 + x1, x0) of x0^2 + x0*x1 peaks at (1, 1)
+	bs := newTestSpline2D(t)
+	lipschitz, err = bs.LipschitzEstimate(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual(lipschitz, math.Sqrt(10), 1e-6) {
+		t.Errorf("expected %v, got %v", math.Sqrt(10), lipschitz)
+	}
+
+	if _, err := bs.LipschitzEstimate(1); err != ErrInvalidCount {
+		t.Errorf("expected ErrInvalidCount, got %v", err)
+	}
+}
+
+func TestGradientStats(t *testing.T) {
+	// the derivative 2x of x^2 averages 2 over an even grid on [0, 2] and peaks at the right end
+	mean, max, at, err := newTestSpline1D(t).GradientStats(11)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual(mean, 2, 1e-6) || !almostEqual(max, 4, 1e-6) {
+		t.Errorf("expected mean 2 and max 4, got %v and %v", mean, max)
+	}
+	if len(at) != 1 || !almostEqual(at[0], 2, 1e-12) {
+		t.Errorf("expected the maximum at [2], got %v", at)
+	}
+
+	bs := newTestSpline2D(t)
+	_, max, at, err = bs.GradientStats(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual(max, math.Sqrt(10), 1e-6) || len(at) != 2 || at[0] != 1 || at[1] != 1 {
+		t.Errorf("expected max %v at [1 1], got %v at %v", math.Sqrt(10), max, at)
+	}
+
+	if _, _, _, err := bs.GradientStats(1); err != ErrInvalidCount {
+		t.Errorf("expected ErrInvalidCount, got %v", err)
+	}
+}
+
+func TestEvalValueGradHessBatch(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	points := [][]float64{{0.5, 0.25}, {0.1, 0.9}}
+	values, grads, hessians, err := bs.EvalValueGradHessBatch(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, p := range points {
+		// x0^2 + x0*x1 has gradient (2x0 + x1, x0) and constant Hessian [[2 1] [1 0]]
+		if !almostEqual(values[k], bilinearish(p), 1e-9) {
+			t.Errorf("point %v: expected value %v, got %v", p, bilinearish(p), values[k])
+		}
+		if !almostEqual(grads[k][0], 2*p[0]+p[1], 1e-9) || !almostEqual(grads[k][1], p[0], 1e-9) {
+			t.Errorf("point %v: unexpected gradient %v", p, grads[k])
+		}
+		expected := [][]float64{{2, 1}, {1, 0}}
+		for i := range expected {
+			for j := range expected[i] {
+				if !almostEqual(hessians[k][i][j], expected[i][j], 1e-6) {
+					t.Errorf("point %v: unexpected Hessian %v", p, hessians[k])
+				}
+			}
+		}
+	}
+
+	if _, _, _, err := bs.EvalValueGradHessBatch([][]float64{{0.5, 0.5}, {0.5}}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if values, _, _, err := bs.EvalValueGradHessBatch(nil); err != nil || len(values) != 0 {
+		t.Errorf("expected no values for no points, got %v, %v", values, err)
+	}
+}
+
+func TestIsLinear(t *testing.T) {
+	plane := buildTestSpline(t, [][]float64{linspace(0, 1, 6), linspace(-1, 1, 5)}, func(x []float64) float64 {
+		return 2*x[0] - 3*x[1] + 1
+	})
+	linear, curvature, err := plane.IsLinear(1e-8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !linear {
+		t.Errorf("expected a plane to be linear, got curvature %v", curvature)
+	}
+
+	// the Hessian of x0² + x0*x1 is [[2 1] [1 0]], with norm √6
+	linear, curvature, err = newTestSpline2D(t).IsLinear(1e-8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if linear || !almostEqual(curvature, math.Sqrt(6), 1e-6) {
+		t.Errorf("expected curvature √6 and not linear, got %v, %v", curvature, linear)
+	}
+
+	if _, _, err := plane.IsLinear(-1); err != ErrInvalidTolerance {
+		t.Errorf("expected ErrInvalidTolerance, got %v", err)
+	}
+}
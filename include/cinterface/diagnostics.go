@@ -0,0 +1,247 @@
+package splinter
+
+import (
+	"math"
+)
+
+// normalMatrix assembles the left-hand side splinter solves for the coefficients of a spline with the given basis,
+// fitted to the samples x with the settings in cfg:
+//   - SmoothingNone:     BᵀB
+//   - SmoothingIdentity: BᵀB + alpha*I
+//   - SmoothingPspline:  BᵀWB + alpha*DᵀD
+//
+// where B holds the basis functions evaluated at the samples, W the weights and D the second order differences.
+func normalMatrix(basis tensorBasis, x [][]float64, cfg BuilderConfig) [][]float64 {
+	n := basis.numBasisFunctions()
+	a := newMatrix(n, n)
+
+	for s, row := range x {
+		indices, values := basis.eval(row)
+		w := sampleWeight(cfg, len(x), s)
+		for i, ii := range indices {
+			for j, jj := range indices {
+				a[ii][jj] += w * values[i] * values[j]
+			}
+		}
+	}
+
+	switch cfg.Smoothing {
+	case SmoothingIdentity:
+		for i := range a {
+			a[i][i] += cfg.Alpha
+		}
+	case SmoothingPspline:
+		addDifferencePenalty(a, basis.dims(), cfg.Alpha)
+	}
+	return a
+}
+
+// sampleWeight returns the weight splinter gives sample s of n when fitting with cfg.
+func sampleWeight(cfg BuilderConfig, n, s int) float64 {
+	if cfg.Smoothing == SmoothingPspline && len(cfg.Weights) == n {
+		return cfg.Weights[s]
+	}
+	return 1
+}
+
+// hatDiagonal returns the diagonal of the hat matrix of a fit with the settings in cfg, H_ss = w_s b_sᵀ A⁻¹ b_s for
+// each sample s in x, where b_s holds the basis functions at the sample, w_s its weight and L Lᵀ = A the normal
+// matrix of the fit.
+func hatDiagonal(basis tensorBasis, l [][]float64, x [][]float64, cfg BuilderConfig) []float64 {
+	hat := make([]float64, len(x))
+	b := make([]float64, basis.numBasisFunctions())
+	for s, row := range x {
+		indices, values := basis.eval(row)
+		for i, idx := range indices {
+			b[idx] = values[i]
+		}
+		hat[s] = sampleWeight(cfg, len(x), s) * dot(b, choleskySolve(l, b))
+		for _, idx := range indices {
+			b[idx] = 0
+		}
+	}
+	return hat
+}
+
+// addDifferencePenalty adds alpha*DᵀD to a, where D is the second order finite difference matrix built the same way as
+// splinter's BSpline::Builder::getSecondOrderFiniteDifferenceMatrix.
+func addDifferencePenalty(a [][]float64, dims []int, alpha float64) {
+	for d := range dims {
+		leftProd, rightProd := 1, 1
+		for k := 0; k < d; k++ {
+			leftProd *= dims[k]
+		}
+		for k := d + 1; k < len(dims); k++ {
+			rightProd *= dims[k]
+		}
+
+		for j := 0; j < rightProd; j++ {
+			for l := 0; l < dims[d]-2; l++ {
+				for m := 0; m < leftProd; m++ {
+					// one row of D: [1 -2 1] at columns k, k+leftProd, k+2*leftProd
+					k := j*leftProd*dims[d] + l*leftProd + m
+					cols := [3]int{k, k + leftProd, k + 2*leftProd}
+					coeffs := [3]float64{1, -2, 1}
+					for p := range cols {
+						for q := range cols {
+							a[cols[p]][cols[q]] += alpha * coeffs[p] * coeffs[q]
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// ConditionNumber estimates the condition number of the linear system solved when building, as a warning sign for
+// numerically shaky fits: large values suggest adding smoothing or reducing the number of basis functions.
+//
+// splinter does not expose its system matrix, so the builder builds a spline to learn the knot vectors and then
+// reassembles the system on the Go side from the samples and the configured smoothing, alpha and weights (alpha as
+// set, before any HFS iterations). The ratio of extreme eigenvalues is estimated by power iteration. Without
+// smoothing, splinter solves the (possibly non-square) collocation system directly, so the square root of the normal
+// matrix condition number is reported. Memory use is quadratic in the number of basis functions.
+func (builder *BSplineBuilder) ConditionNumber() (float64, error) {
+	bs, err := builder.Build()
+	if err != nil {
+		return 0, err
+	}
+	defer bs.Free()
+
+	basis, err := bs.basis()
+	if err != nil {
+		return 0, err
+	}
+
+	return systemConditionNumber(basis, builder.x, builder.config), nil
+}
+
+// systemConditionNumber estimates the condition number of the system splinter solves to fit a spline with the given
+// basis to the samples x with cfg, see BSplineBuilder.ConditionNumber.
+func systemConditionNumber(basis tensorBasis, x [][]float64, cfg BuilderConfig) float64 {
+	cond := conditionNumber(normalMatrix(basis, x, cfg))
+	if cfg.Smoothing == SmoothingNone {
+		cond = math.Sqrt(cond)
+	}
+	return cond
+}
+
+// AppliedWeights returns the sample weights the spline was fitted with, in the order of DataTable.Samples. splinter
+// only applies weights to P-spline fits (SmoothingPspline), so nil is returned for other fits, for fits without
+// weights, and for splines that were not built in this process.
+func (bs *BSpline) AppliedWeights() ([]float64, error) {
+	if bs.weights == nil {
+		return nil, nil
+	}
+	return append([]float64(nil), bs.weights...), nil
+}
+
+// Diagnostics summarizes a fitted spline for logging, see BSpline.Diagnostics.
+type Diagnostics struct {
+	NumSamples        int         `json:"numSamples"`
+	NumVariables      int         `json:"numVariables"`
+	Degrees           []int       `json:"degrees"`
+	NumBasisFunctions []int       `json:"numBasisFunctions"`
+	NumCoefficients   int         `json:"numCoefficients"`
+	NumKnots          []int       `json:"numKnots"`
+	ConditionNumber   float64     `json:"conditionNumber"`
+	FitMethod         string      `json:"fitMethod"`
+	KnotSpacing       KnotSpacing `json:"knotSpacing"`
+	Smoothing         Smoothing   `json:"smoothing"`
+	Alpha             float64     `json:"alpha"`
+}
+
+// maxDiagnosticsBasisFunctions is the largest number of basis functions for which Diagnostics estimates the condition
+// number, which takes a dense matrix of that size squared and a Cholesky factorization of it.
+const maxDiagnosticsBasisFunctions = 500
+
+// fitMethods describes the fit splinter performs for each kind of smoothing.
+var fitMethods = map[Smoothing]string{
+	SmoothingNone:     "least squares",
+	SmoothingIdentity: "ridge regression",
+	SmoothingPspline:  "penalized spline",
+}
+
+// Diagnostics reports the samples, basis, knots, build settings and condition number estimate (see
+// BSplineBuilder.ConditionNumber) of the spline. Whatever cannot be determined is left at its zero value instead of
+// failing the call: in particular, the sample count, settings and condition number are only known for splines built
+// in this process. A singular system is reported with the condition number math.MaxFloat64, which, unlike +Inf, can
+// be encoded as JSON.
+//
+// The condition number takes memory quadratic and time cubic in the number of basis functions, so to keep the call
+// cheap it is only estimated for splines with at most 500 basis functions, and left at zero for larger ones;
+// BSplineBuilder.ConditionNumber computes it regardless.
+func (bs *BSpline) Diagnostics() (Diagnostics, error) {
+	var d Diagnostics
+
+	if degrees, err := bs.Degrees(); err == nil {
+		d.Degrees = degrees
+		d.NumVariables = len(degrees)
+	}
+	if knotVectors, err := bs.KnotVectors(); err == nil {
+		d.NumKnots = make([]int, len(knotVectors))
+		for i, knots := range knotVectors {
+			d.NumKnots[i] = len(knots)
+		}
+	}
+	if coefficients, err := bs.GetCoefficients(); err == nil {
+		d.NumCoefficients = len(coefficients)
+	}
+
+	basis, err := bs.basis()
+	if err == nil {
+		d.NumBasisFunctions = basis.dims()
+	}
+
+	if bs.config != nil {
+		d.NumSamples = len(bs.y)
+		d.KnotSpacing = bs.config.KnotSpacing
+		d.Smoothing = bs.config.Smoothing
+		d.Alpha = bs.config.Alpha
+		d.FitMethod = fitMethods[bs.config.Smoothing]
+
+		if err == nil && d.NumCoefficients <= maxDiagnosticsBasisFunctions {
+			d.ConditionNumber = math.Min(systemConditionNumber(basis, bs.x, *bs.config), math.MaxFloat64)
+		}
+	}
+	return d, nil
+}
+
+// Leverage returns the leverage of each sample in dt, the diagonal entry of the hat matrix H that maps responses to
+// fitted values. Samples with leverage close to 1 pull the fit through themselves and dominate it locally.
+//
+// splinter does not expose H, so it is computed on the Go side from the spline's basis and the smoothing, alpha and
+// weights it was built with, like LooCV. For samples of dt that are not the samples the spline was built from, the
+// value is the leverage the sample would have with unit weight, b(x)ᵀ A⁻¹ b(x), without refitting. Only splines
+// returned by a builder have the settings this needs; ErrSingularSystem is returned if the fit's system is singular.
+func (bs *BSpline) Leverage(dt *DataTable) ([]float64, error) {
+	if dt == nil {
+		return nil, ErrInvalidNil
+	}
+	if bs.config == nil {
+		return nil, ErrNoTrainingData
+	}
+
+	n, err := bs.NumVariables()
+	if err != nil {
+		return nil, err
+	}
+	if len(dt.y) > 0 && len(dt.x[0]) != n {
+		return nil, ErrDimensionMismatch
+	}
+
+	basis, err := bs.basis()
+	if err != nil {
+		return nil, err
+	}
+	l, ok := cholesky(normalMatrix(basis, bs.x, *bs.config))
+	if !ok {
+		return nil, ErrSingularSystem
+	}
+
+	cfg := *bs.config
+	if !bs.builtFrom(dt) {
+		cfg.Weights = nil
+	}
+	return hatDiagonal(basis, l, dt.x, cfg), nil
+}
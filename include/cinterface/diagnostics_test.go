@@ -0,0 +1,202 @@
+package splinter
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestConditionNumber(t *testing.T) {
+	dt, err := newTestSpline1D(t).Resample([][]float64{linspace(0, 2, 21)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	builder, err := NewBSplineBuilder(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cond, err := builder.ConditionNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cond < 1 || math.IsInf(cond, 0) || math.IsNaN(cond) {
+		t.Errorf("expected a finite condition number >= 1, got %v", cond)
+	}
+
+	// heavy ridge regularization makes the system close to a multiple of the identity
+	if err := builder.Smoothing(SmoothingIdentity); err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.Alpha(1e6); err != nil {
+		t.Fatal(err)
+	}
+	cond, err = builder.ConditionNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cond < 1 || cond > 1.01 {
+		t.Errorf("expected a condition number close to 1, got %v", cond)
+	}
+}
+
+func TestAppliedWeights(t *testing.T) {
+	dt, err := newTestSpline1D(t).Resample([][]float64{linspace(0, 2, 21)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	builder, err := NewBSplineBuilder(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	weights := linspace(1, 2, 21)
+	if err := builder.Weights(weights); err != nil {
+		t.Fatal(err)
+	}
+
+	// weights are ignored without P-spline smoothing
+	bs, err := builder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if applied, err := bs.AppliedWeights(); err != nil || applied != nil {
+		t.Errorf("expected no applied weights, got %v, %v", applied, err)
+	}
+
+	if err := builder.Smoothing(SmoothingPspline); err != nil {
+		t.Fatal(err)
+	}
+	bs, err = builder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	applied, err := bs.AppliedWeights()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(applied, weights) {
+		t.Errorf("expected %v, got %v", weights, applied)
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	d, err := bs.Diagnostics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.NumSamples != 121 || d.NumVariables != 2 || d.NumCoefficients != 121 {
+		t.Errorf("unexpected counts %+v", d)
+	}
+	if !reflect.DeepEqual(d.Degrees, []int{3, 3}) || !reflect.DeepEqual(d.NumBasisFunctions, []int{11, 11}) {
+		t.Errorf("unexpected basis %+v", d)
+	}
+	if !reflect.DeepEqual(d.NumKnots, []int{15, 15}) {
+		t.Errorf("unexpected knot counts %v", d.NumKnots)
+	}
+	if d.FitMethod != "least squares" || d.ConditionNumber < 1 {
+		t.Errorf("unexpected fit description %+v", d)
+	}
+	if _, err := json.Marshal(d); err != nil {
+		t.Errorf("expected diagnostics to encode as JSON, got %v", err)
+	}
+
+	// a spline not built in this process has no build information
+	clone, err := bs.clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err = clone.Diagnostics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.NumSamples != 0 || d.FitMethod != "" || d.ConditionNumber != 0 || d.NumCoefficients != 121 {
+		t.Errorf("unexpected diagnostics for a loaded spline %+v", d)
+	}
+
+	// the condition number is skipped for large bases
+	large := buildTestSpline(t, [][]float64{linspace(0, 1, 25), linspace(0, 1, 25)}, bilinearish)
+	d, err = large.Diagnostics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.NumCoefficients <= maxDiagnosticsBasisFunctions || d.FitMethod == "" || d.ConditionNumber != 0 {
+		t.Errorf("expected no condition number for %d basis functions, got %+v", d.NumCoefficients, d)
+	}
+}
+
+func TestLeverage(t *testing.T) {
+	dt := noisyTable(t)
+	cfg := BuilderConfig{Smoothing: SmoothingPspline, Alpha: 0.5}
+	bs, err := Fit(dt, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leverage, err := bs.Leverage(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(leverage) != 40 {
+		t.Fatalf("expected 40 leverages, got %d", len(leverage))
+	}
+	trace := 0.0
+	for _, h := range leverage {
+		if h <= 0 || h >= 1 {
+			t.Errorf("expected leverages strictly between 0 and 1, got %v", h)
+		}
+		trace += h
+	}
+	// the trace is the effective number of parameters, below the 40 of an interpolant
+	if trace >= 40 {
+		t.Errorf("expected smoothing to reduce the effective number of parameters, got %v", trace)
+	}
+
+	// the leverage is the sensitivity of the fitted value to the sample's own response
+	const s, delta = 7, 1e-3
+	x, y := dt.Samples()
+	y[s] += delta
+	perturbed, err := newDataTableFromSamples(x, y)
+	if err != nil {
+		t.Fatal(err)
+	}
+	refit, err := Fit(perturbed, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, err := bs.Eval(x[s]...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	after, err := refit.Eval(x[s]...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual((after-before)/delta, leverage[s], 1e-6) {
+		t.Errorf("expected leverage %v to match the sensitivity %v", leverage[s], (after-before)/delta)
+	}
+
+	// an interpolant has unit leverage everywhere
+	interpolant, err := Fit(dt, BuilderConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	leverage, err = interpolant.Leverage(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, h := range leverage {
+		if !almostEqual(h, 1, 1e-6) {
+			t.Errorf("expected unit leverage for an interpolant, got %v", h)
+			break
+		}
+	}
+
+	if _, err := newTestSpline2D(t).Leverage(dt); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
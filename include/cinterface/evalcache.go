@@ -0,0 +1,90 @@
+package splinter
+
+import (
+	"container/list"
+	"encoding/binary"
+	"math"
+)
+
+// EvalCache evaluates a spline through a least recently used cache, so repeated evaluations at the same points skip
+// the call into splinter. Inputs are quantized to a grid of the given granularity before lookup and the spline is
+// evaluated at the quantized point, so all inputs rounding to the same grid point share one cached value: this trades
+// memory, and an error of up to granularity/2 in each input, for speed. It is not safe for concurrent use.
+type EvalCache struct {
+	bs          *BSpline
+	size        int
+	granularity float64
+
+	// entries holds the cached values, most recently used first; index maps keys to their element
+	entries *list.List
+	index   map[string]*list.Element
+	key     []byte
+	point   []float64
+}
+
+type evalCacheEntry struct {
+	key   string
+	value float64
+}
+
+// NewEvalCache returns a cache of up to size values of bs. A granularity of 0 disables quantization, so only exactly
+// repeated inputs hit the cache.
+func NewEvalCache(bs *BSpline, size int, granularity float64) (*EvalCache, error) {
+	if bs == nil {
+		return nil, ErrInvalidNil
+	}
+	if size <= 0 {
+		return nil, ErrInvalidCount
+	}
+	if !(granularity >= 0) || math.IsInf(granularity, 0) {
+		return nil, ErrInvalidTolerance
+	}
+	return &EvalCache{
+		bs:          bs,
+		size:        size,
+		granularity: granularity,
+		entries:     list.New(),
+		index:       make(map[string]*list.Element),
+	}, nil
+}
+
+// Eval returns the value of the spline at the quantized point, from the cache if it holds it.
+func (ec *EvalCache) Eval(vals ...float64) (float64, error) {
+	ec.key = ec.key[:0]
+	ec.point = ec.point[:0]
+	for _, v := range vals {
+		if ec.granularity > 0 {
+			v = math.Round(v/ec.granularity) * ec.granularity
+		}
+		ec.point = append(ec.point, v)
+
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(v))
+		ec.key = append(ec.key, buf[:]...)
+	}
+
+	// the conversion in the lookup does not allocate
+	if e, ok := ec.index[string(ec.key)]; ok {
+		ec.entries.MoveToFront(e)
+		return e.Value.(*evalCacheEntry).value, nil
+	}
+
+	value, err := ec.bs.Eval(ec.point...)
+	if err != nil {
+		return 0, err
+	}
+
+	key := string(ec.key)
+	ec.index[key] = ec.entries.PushFront(&evalCacheEntry{key: key, value: value})
+	if ec.entries.Len() > ec.size {
+		oldest := ec.entries.Back()
+		ec.entries.Remove(oldest)
+		delete(ec.index, oldest.Value.(*evalCacheEntry).key)
+	}
+	return value, nil
+}
+
+// Len returns the number of cached values.
+func (ec *EvalCache) Len() int {
+	return ec.entries.Len()
+}
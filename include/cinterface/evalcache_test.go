@@ -0,0 +1,61 @@
+package splinter
+
+import (
+	"testing"
+)
+
+func TestEvalCache(t *testing.T) {
+	bs := newTestSpline1D(t)
+	ec, err := NewEvalCache(bs, 2, 0.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 1.02 and 0.98 both round to 1, where the spline is evaluated
+	for _, x := range []float64{1.02, 0.98} {
+		v, err := ec.Eval(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !almostEqual(v, 1, 1e-9) {
+			t.Errorf("x=%v: expected the value at 1, got %v", x, v)
+		}
+	}
+	if ec.Len() != 1 {
+		t.Errorf("expected 1 cached value, got %d", ec.Len())
+	}
+
+	// the least recently used value is evicted
+	for _, x := range []float64{1.5, 1, 0.5} {
+		if _, err := ec.Eval(x); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if ec.Len() != 2 {
+		t.Errorf("expected the cache to stay at 2 values, got %d", ec.Len())
+	}
+	if _, ok := ec.index[string(ec.key)]; !ok {
+		t.Errorf("expected the last value to be cached")
+	}
+	if _, err := ec.Eval(1, 2); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if ec.Len() != 2 {
+		t.Errorf("failed evaluations should not be cached")
+	}
+
+	exact, err := NewEvalCache(bs, 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := exact.Eval(1.02); err != nil || !almostEqual(v, 1.02*1.02, 1e-9) {
+		t.Errorf("expected the exact value without quantization, got %v (%v)", v, err)
+	}
+
+	if _, err := NewEvalCache(bs, 0, 0.1); err != ErrInvalidCount {
+		t.Errorf("expected ErrInvalidCount, got %v", err)
+	}
+	if _, err := NewEvalCache(bs, 10, -1); err != ErrInvalidTolerance {
+		t.Errorf("expected ErrInvalidTolerance, got %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package splinter
+
+import (
+	"math"
+	"sort"
+)
+
+type EvalMode int
+
+const (
+	EvalPrecise EvalMode = 0
+	EvalFast             = 1
+)
+
+// fastEvaluator evaluates a spline on the Go side, avoiding the overhead of calling into splinter. It remembers the
+// knot span of the previous point in each variable, so evaluating nearby points skips the span search.
+type fastEvaluator struct {
+	basis        tensorBasis
+	coefficients []float64
+	strides      []int
+	nonNegative  bool
+
+	// scratch space reused between evaluations
+	spans   []int
+	values  [][]float64
+	counter []int
+}
+
+func newFastEvaluator(bs *BSpline) (*fastEvaluator, error) {
+	basis, err := bs.basis()
+	if err != nil {
+		return nil, err
+	}
+	coefficients, err := bs.GetCoefficients()
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(basis)
+	fe := &fastEvaluator{
+		basis:        basis,
+		coefficients: coefficients,
+		strides:      make([]int, n),
+		spans:        make([]int, n),
+		values:       make([][]float64, n),
+		counter:      make([]int, n),
+		nonNegative:  bs.nonNegative,
+	}
+	stride := 1
+	for d := n - 1; d >= 0; d-- {
+		fe.strides[d] = stride
+		stride *= basis[d].numBasisFunctions()
+		fe.spans[d] = basis[d].degree
+	}
+	return fe, nil
+}
+
+func (fe *fastEvaluator) eval(x []float64) (float64, error) {
+	if len(x) != len(fe.basis) {
+		return 0, ErrDimensionMismatch
+	}
+
+	first := 0
+	for d, b := range fe.basis {
+		if !b.insideSupport(x[d]) {
+			// like splinter, where all basis functions vanish
+			return 0, nil
+		}
+
+		v := x[d]
+		last := b.knots[len(b.knots)-1]
+		i := fe.spans[d]
+		if !(b.knots[i] <= v && v < b.knots[i+1]) {
+			i = b.span(v)
+			fe.spans[d] = i
+		}
+		if v == last {
+			v = math.Nextafter(v, math.Inf(-1))
+		}
+
+		fe.values[d] = b.evalSpan(v, i)
+		first += (i - b.degree) * fe.strides[d]
+	}
+
+	// sum the coefficients weighted by the tensor products of the non-zero basis functions, enumerating the
+	// (degree+1)^n combinations with an odometer
+	n := len(fe.basis)
+	counter := fe.counter
+	res := 0.0
+	for {
+		index := first
+		weight := 1.0
+		for d, k := range counter {
+			index += k * fe.strides[d]
+			weight *= fe.values[d][k]
+		}
+		res += weight * fe.coefficients[index]
+
+		d := n - 1
+		for ; d >= 0; d-- {
+			counter[d]++
+			if counter[d] <= fe.basis[d].degree {
+				break
+			}
+			counter[d] = 0
+		}
+		if d < 0 {
+			if res < 0 && fe.nonNegative {
+				res = clampNegative(x, res)
+			}
+			return res, nil
+		}
+	}
+}
+
+// SetEvalMode chooses how Eval computes values. EvalPrecise, the default, calls into splinter. splinter has no faster
+// evaluation of its own, so EvalFast evaluates the spline on the Go side instead, from a copy of its basis and
+// coefficients: this saves the cost of a cgo call per point and, for points close to the previous one, the search
+// for the knot span. The results are the same up to rounding (relative differences around 1e-15), as the same
+// recurrence is used in a different order. EvalFast mutates the span cache on every call, so a spline in that mode
+// must not be evaluated from several goroutines at once.
+func (bs *BSpline) SetEvalMode(mode EvalMode) error {
+	switch mode {
+	case EvalPrecise:
+		bs.fast = nil
+		return nil
+	case EvalFast:
+		fe, err := newFastEvaluator(bs)
+		if err != nil {
+			return err
+		}
+		bs.fast = fe
+		return nil
+	}
+	return ErrInvalidMode
+}
+
+// EvalBatchSorted evaluates the spline at each of the given points, returning the values in the order of points.
+// Internally the points are sorted by knot span, variable by variable, and evaluated in that order on the Go side like
+// EvalFast: consecutive points then mostly share their knot spans and basis functions, so the span search is skipped
+// and the coefficients they read stay in cache. The gain over EvalBatch, which goes through splinter, is largest for
+// many clustered points; the results are the same up to rounding. bs itself is not modified, so this is safe to call
+// concurrently.
+func (bs *BSpline) EvalBatchSorted(points [][]float64) (values []float64, err error) {
+	fe, err := newFastEvaluator(bs)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(fe.basis)
+	spans := make([][]int, len(points))
+	order := make([]int, len(points))
+	for k, point := range points {
+		if len(point) != n {
+			return nil, ErrInvalidPoint{Index: k, Err: ErrDimensionMismatch}
+		}
+		spans[k] = make([]int, n)
+		for d, b := range fe.basis {
+			// points outside the support evaluate to 0 without needing a span
+			spans[k][d] = -1
+			if b.insideSupport(point[d]) {
+				spans[k][d] = b.span(point[d])
+			}
+		}
+		order[k] = k
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := spans[order[i]], spans[order[j]]
+		for d := range a {
+			if a[d] != b[d] {
+				return a[d] < b[d]
+			}
+		}
+		return false
+	})
+
+	values = make([]float64, len(points))
+	for _, k := range order {
+		values[k], err = fe.eval(points[k])
+		if err != nil {
+			return nil, ErrInvalidPoint{Index: k, Err: err}
+		}
+	}
+	return values, nil
+}
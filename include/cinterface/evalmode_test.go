@@ -0,0 +1,136 @@
+package splinter
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSetEvalMode(t *testing.T) {
+	for _, bs := range []*BSpline{newTestSpline1D(t), newTestSpline2D(t)} {
+		domain, err := bs.GetDomain()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// a sweep of nearby points, including the right end and a point outside the domain
+		var points [][]float64
+		for _, s := range linspace(-0.1, 1, 112) {
+			point := make([]float64, len(domain))
+			for i, d := range domain {
+				point[i] = d[0] + s*(d[1]-d[0])
+			}
+			points = append(points, point)
+		}
+
+		precise := make([]float64, len(points))
+		for k, point := range points {
+			if precise[k], err = bs.Eval(point...); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if err := bs.SetEvalMode(EvalFast); err != nil {
+			t.Fatal(err)
+		}
+		for k, point := range points {
+			v, err := bs.Eval(point...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !almostEqual(v, precise[k], 1e-12) {
+				t.Errorf("at %v: expected %v, got %v", point, precise[k], v)
+			}
+		}
+
+		if _, err := bs.Eval(make([]float64, len(domain)+1)...); err != ErrDimensionMismatch {
+			t.Errorf("expected ErrDimensionMismatch, got %v", err)
+		}
+	}
+
+	// the fast path follows coefficient changes
+	bs := newTestSpline1D(t)
+	if err := bs.SetEvalMode(EvalFast); err != nil {
+		t.Fatal(err)
+	}
+	coeffs, err := bs.GetCoefficients()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range coeffs {
+		coeffs[i] = 1
+	}
+	if err := bs.SetCoefficients(coeffs); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := bs.Eval(0.7); err != nil || !almostEqual(v, 1, 1e-12) {
+		t.Errorf("expected 1 after setting unit coefficients, got %v (%v)", v, err)
+	}
+
+	if err := bs.SetEvalMode(EvalMode(5)); err != ErrInvalidMode {
+		t.Errorf("expected ErrInvalidMode, got %v", err)
+	}
+}
+
+func TestEvalBatchSorted(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	rng := rand.New(rand.NewSource(3))
+	points := make([][]float64, 200)
+	for k := range points {
+		points[k] = []float64{rng.Float64(), rng.Float64()}
+	}
+	// outside the domain and on its corner
+	points = append(points, []float64{1.5, 0.5}, []float64{1, 1})
+
+	values, err := bs.EvalBatchSorted(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := bs.evalSamples(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k := range points {
+		if !almostEqual(values[k], expected[k], 1e-12) {
+			t.Errorf("point %v: expected %v, got %v", points[k], expected[k], values[k])
+		}
+	}
+
+	_, err = bs.EvalBatchSorted([][]float64{{0.5, 0.5}, {0.5}})
+	if e, ok := err.(ErrInvalidPoint); !ok || e.Index != 1 || e.Err != ErrDimensionMismatch {
+		t.Errorf("expected point 1 to mismatch, got %v", err)
+	}
+}
+
+// clusteredPoints returns n points around a few centres in [0, 1]^2, in random order.
+func clusteredPoints(n int) [][]float64 {
+	rng := rand.New(rand.NewSource(1))
+	points := make([][]float64, n)
+	for k := range points {
+		c := float64(rng.Intn(4))/4 + 0.125
+		points[k] = []float64{
+			math.Min(math.Max(c+0.02*rng.NormFloat64(), 0), 1),
+			math.Min(math.Max(c+0.02*rng.NormFloat64(), 0), 1),
+		}
+	}
+	return points
+}
+
+func BenchmarkEvalBatch(b *testing.B) {
+	bs := buildTestSpline(b, [][]float64{linspace(0, 1, 41), linspace(0, 1, 41)}, bilinearish)
+	points := clusteredPoints(10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bs.EvalBatch(points)
+	}
+}
+
+func BenchmarkEvalBatchSorted(b *testing.B) {
+	bs := buildTestSpline(b, [][]float64{linspace(0, 1, 41), linspace(0, 1, 41)}, bilinearish)
+	points := clusteredPoints(10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bs.EvalBatchSorted(points)
+	}
+}
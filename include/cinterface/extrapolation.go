@@ -0,0 +1,98 @@
+package splinter
+
+import (
+	"math"
+)
+
+type ExtrapPolicy int
+
+const (
+	// ExtrapExtend continues the polynomial pieces at the boundary of the domain beyond it.
+	ExtrapExtend ExtrapPolicy = 0
+	// ExtrapClamp evaluates at the nearest point of the domain, which makes the spline constant beyond the boundary
+	// along each axis.
+	ExtrapClamp = 1
+	// ExtrapNaN returns NaN outside the domain.
+	ExtrapNaN = 2
+	// ExtrapError returns ErrOutsideDomain outside the domain.
+	ExtrapError = 3
+)
+
+// EvalPolicy evaluates the spline at a point, applying policy if the point lies outside the domain (see GetDomain).
+// Inside the domain every policy gives the same result as Eval, which is left unchanged: outside, Eval gets
+// splinter's own behavior, where the basis functions vanish.
+func (bs *BSpline) EvalPolicy(policy ExtrapPolicy, vals ...float64) (float64, error) {
+	if policy < ExtrapExtend || policy > ExtrapError {
+		return 0, ErrInvalidPolicy
+	}
+
+	domain, err := bs.GetDomain()
+	if err != nil {
+		return 0, err
+	}
+	if len(vals) != len(domain) {
+		return 0, ErrDimensionMismatch
+	}
+
+	if insideBounds(vals, domain) {
+		return bs.Eval(vals...)
+	}
+
+	switch policy {
+	case ExtrapClamp:
+		x := make([]float64, len(vals))
+		for i, v := range vals {
+			x[i] = math.Max(domain[i][0], math.Min(v, domain[i][1]))
+		}
+		return bs.Eval(x...)
+	case ExtrapNaN:
+		return math.NaN(), nil
+	case ExtrapError:
+		return 0, ErrOutsideDomain
+	}
+
+	// splinter cannot evaluate outside the domain, so the boundary pieces are evaluated on the Go side
+	basis, err := bs.basis()
+	if err != nil {
+		return 0, err
+	}
+	coefficients, err := bs.GetCoefficients()
+	if err != nil {
+		return 0, err
+	}
+
+	indices, values := basis.evalExtended(vals)
+	res := 0.0
+	for i, idx := range indices {
+		res += coefficients[idx] * values[i]
+	}
+	return res, nil
+}
+
+// EvalOrNearest evaluates the spline at a point, falling back to the response of the sample in dt nearest to the point
+// (in Euclidean distance) when the spline cannot give a meaningful value there, for callers that prefer a rough
+// answer to an error. That is the case when Eval fails, returns a value that is not finite, or when the point lies
+// outside the domain, where splinter silently returns 0. fellBack reports whether the sample was used; an error is
+// only returned if dt cannot provide one, in which case the error of Eval takes precedence.
+func (bs *BSpline) EvalOrNearest(dt *DataTable, vals ...float64) (value float64, fellBack bool, err error) {
+	if dt == nil {
+		return 0, false, ErrInvalidNil
+	}
+
+	value, err = bs.Eval(vals...)
+	if err == nil && !math.IsNaN(value) && !math.IsInf(value, 0) {
+		domain, domainErr := bs.GetDomain()
+		if domainErr == nil && insideBounds(vals, domain) {
+			return value, false, nil
+		}
+	}
+
+	nearest, nearestErr := dt.nearestSample(vals)
+	if nearestErr != nil {
+		if err != nil {
+			return 0, false, err
+		}
+		return 0, false, nearestErr
+	}
+	return dt.y[nearest], true, nil
+}
@@ -0,0 +1,82 @@
+package splinter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEvalPolicy(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	// inside the domain every policy agrees with Eval
+	for policy := ExtrapExtend; policy <= ExtrapError; policy++ {
+		v, err := bs.EvalPolicy(policy, 0.3, 0.6)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !almostEqual(v, bilinearish([]float64{0.3, 0.6}), 1e-9) {
+			t.Errorf("policy %d: expected %v, got %v", policy, bilinearish([]float64{0.3, 0.6}), v)
+		}
+	}
+
+	// the boundary pieces of x0^2 + x0*x1 are the polynomial itself
+	outside := []float64{1.5, -0.5}
+	v, err := bs.EvalPolicy(ExtrapExtend, outside...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual(v, bilinearish(outside), 1e-9) {
+		t.Errorf("extend: expected %v, got %v", bilinearish(outside), v)
+	}
+
+	v, err = bs.EvalPolicy(ExtrapClamp, outside...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual(v, bilinearish([]float64{1, 0}), 1e-9) {
+		t.Errorf("clamp: expected %v, got %v", bilinearish([]float64{1, 0}), v)
+	}
+
+	if v, err := bs.EvalPolicy(ExtrapNaN, outside...); err != nil || !math.IsNaN(v) {
+		t.Errorf("expected NaN, got %v (%v)", v, err)
+	}
+	if _, err := bs.EvalPolicy(ExtrapError, outside...); err != ErrOutsideDomain {
+		t.Errorf("expected ErrOutsideDomain, got %v", err)
+	}
+	if _, err := bs.EvalPolicy(ExtrapPolicy(9), outside...); err != ErrInvalidPolicy {
+		t.Errorf("expected ErrInvalidPolicy, got %v", err)
+	}
+}
+
+func TestEvalOrNearest(t *testing.T) {
+	bs := newTestSpline1D(t)
+	dt, err := bs.Resample([][]float64{linspace(0, 2, 5)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, fellBack, err := bs.EvalOrNearest(dt, 1.5)
+	if err != nil || fellBack || !almostEqual(v, 2.25, 1e-9) {
+		t.Errorf("expected 2.25 from the spline, got %v, %v, %v", v, fellBack, err)
+	}
+
+	// beyond the domain the nearest sample is the one at 2
+	v, fellBack, err = bs.EvalOrNearest(dt, 2.7)
+	if err != nil || !fellBack || !almostEqual(v, 4, 1e-9) {
+		t.Errorf("expected 4 from the nearest sample, got %v, %v, %v", v, fellBack, err)
+	}
+
+	if _, _, err := bs.EvalOrNearest(dt, 1, 2); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	empty, err := NewDataTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := bs.EvalOrNearest(empty, 3); err != ErrNoSamples {
+		t.Errorf("expected ErrNoSamples, got %v", err)
+	}
+	if _, _, err := bs.EvalOrNearest(nil, 1); err != ErrInvalidNil {
+		t.Errorf("expected ErrInvalidNil, got %v", err)
+	}
+}
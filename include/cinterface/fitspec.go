@@ -0,0 +1,84 @@
+package splinter
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// fitSpecVersion is the version of the fit specification files written by SaveFitSpec.
+const fitSpecVersion = 1
+
+// fitSpec is the JSON document SaveFitSpec writes: the samples of a table in splinter order, which is the order
+// BuilderConfig.Weights refers to, and the settings of the fit.
+type fitSpec struct {
+	Version int           `json:"version"`
+	X       [][]float64   `json:"x"`
+	Y       []float64     `json:"y"`
+	Config  BuilderConfig `json:"config"`
+}
+
+// SaveFitSpec writes everything needed to reproduce a fit, the samples in dt and the settings in cfg, to a single JSON
+// file at path. Building from what LoadFitSpec returns gives the same spline. Floats are written with enough digits to
+// be read back exactly; infinite or NaN values, which JSON cannot represent, make the call fail. The file is written
+// to a temporary file next to path and renamed into place, so an existing file is never left half written.
+func SaveFitSpec(path string, dt *DataTable, cfg BuilderConfig) error {
+	if dt == nil {
+		return ErrInvalidNil
+	}
+	if len(dt.y) == 0 {
+		return ErrNoSamples
+	}
+
+	data, err := json.Marshal(fitSpec{Version: fitSpecVersion, X: dt.x, Y: dt.y, Config: cfg})
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(path, data)
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it into place, so that readers never see a
+// partially written file at path.
+func writeFileAtomic(path string, data []byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
+
+// LoadFitSpec reads a file written by SaveFitSpec, returning a new table holding its samples and the settings of the
+// fit, ready to be passed to Fit. Files that are not valid specifications are rejected with ErrInvalidFitSpec.
+func LoadFitSpec(path string) (*DataTable, BuilderConfig, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, BuilderConfig{}, err
+	}
+
+	var spec fitSpec
+	if err := json.Unmarshal(data, &spec); err != nil || spec.Version != fitSpecVersion {
+		return nil, BuilderConfig{}, ErrInvalidFitSpec
+	}
+	if err := (Dataset{X: spec.X, Y: spec.Y}).check(); err != nil || len(spec.X[0]) == 0 {
+		return nil, BuilderConfig{}, ErrInvalidFitSpec
+	}
+
+	dt, err := newDataTableFromSamples(spec.X, spec.Y)
+	if err != nil {
+		return nil, BuilderConfig{}, err
+	}
+	return dt, spec.Config, nil
+}
@@ -0,0 +1,76 @@
+package splinter
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFitSpecRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "splinter-fitspec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "fit.json")
+
+	dt := noisyTable(t)
+	weights := make([]float64, 40)
+	for i := range weights {
+		weights[i] = 1 + float64(i%3)
+	}
+	cfg := BuilderConfig{
+		KnotSpacing: KnotSpacingAsSampled,
+		Smoothing:   SmoothingPspline,
+		Alpha:       0.03,
+		Weights:     weights,
+	}
+	if err := SaveFitSpec(path, dt, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, loadedCfg, err := LoadFitSpec(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loadedCfg, cfg) {
+		t.Errorf("expected config %+v, got %+v", cfg, loadedCfg)
+	}
+
+	original, err := Fit(dt, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reproduced, err := Fit(loaded, loadedCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := original.GetCoefficients()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := reproduced.GetCoefficients()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Error("expected the reloaded spec to reproduce the spline exactly")
+	}
+
+	if err := ioutil.WriteFile(path, []byte(`{"version": 1, "x": [[1], [2]], "y": [1]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := LoadFitSpec(path); err != ErrInvalidFitSpec {
+		t.Errorf("expected ErrInvalidFitSpec, got %v", err)
+	}
+
+	empty, err := NewDataTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveFitSpec(path, empty, cfg); err != ErrNoSamples {
+		t.Errorf("expected ErrNoSamples, got %v", err)
+	}
+}
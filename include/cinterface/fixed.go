@@ -0,0 +1,32 @@
+package splinter
+
+import (
+	"math"
+)
+
+// maxFixedScale is the largest decimal scale EvalFixed accepts; 10^18 is the largest power of ten below 2^63.
+const maxFixedScale = 18
+
+// EvalFixed evaluates the spline and returns the value in fixed point, as round(value * 10^scale) with ties rounded
+// to even. Quantizing hides the last-bit differences floating point evaluation shows across platforms, which makes
+// the result usable as a comparison key, except for values that happen to fall within those differences of a
+// rounding boundary. scale must be between 0 and 18, and the scaled value must fit in an int64 (magnitude below about
+// 9.2e18), otherwise ErrOverflow is returned; values are only exact to about 15-16 significant digits, so scales
+// beyond that only add noise.
+func (bs *BSpline) EvalFixed(scale int, vals ...float64) (int64, error) {
+	if scale < 0 || scale > maxFixedScale {
+		return 0, ErrInvalidScale
+	}
+
+	v, err := bs.Eval(vals...)
+	if err != nil {
+		return 0, err
+	}
+
+	scaled := math.RoundToEven(v * math.Pow10(scale))
+	// -2^63 is representable, 2^63 is not
+	if math.IsNaN(scaled) || scaled < math.MinInt64 || scaled >= -math.MinInt64 {
+		return 0, ErrOverflow
+	}
+	return int64(scaled), nil
+}
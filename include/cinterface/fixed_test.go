@@ -0,0 +1,28 @@
+package splinter
+
+import (
+	"testing"
+)
+
+func TestEvalFixed(t *testing.T) {
+	bs := newTestSpline1D(t)
+
+	// 1.5^2 = 2.25
+	for scale, expected := range []int64{2, 22, 225, 2250} {
+		v, err := bs.EvalFixed(scale, 1.5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != expected {
+			t.Errorf("scale %d: expected %d, got %d", scale, expected, v)
+		}
+	}
+
+	if _, err := bs.EvalFixed(19, 1.5); err != ErrInvalidScale {
+		t.Errorf("expected ErrInvalidScale, got %v", err)
+	}
+	large := buildTestSpline(t, [][]float64{linspace(0, 1, 5)}, func(x []float64) float64 { return 10 * x[0] })
+	if _, err := large.EvalFixed(18, 1); err != ErrOverflow {
+		t.Errorf("expected ErrOverflow, got %v", err)
+	}
+}
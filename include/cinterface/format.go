@@ -0,0 +1,64 @@
+package splinter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// supportedFormatVersions lists the SPLINTER versions whose binary spline format can be loaded. Only the 3.0 layout
+// is read: splinter's files carry no version header, so a layout can only be recognised by its structure, and the
+// 2.0 layout cannot be told apart reliably enough to load it without risking silent corruption.
+var supportedFormatVersions = []int{3}
+
+// ErrUnsupportedVersion is returned when loading data whose layout is not that of a spline in one of the Supported
+// SPLINTER versions. Found is the major version the data was written by, or 0 if it is unknown. splinter writes no
+// version header, so for now it is always unknown: the data may come from another SPLINTER version or not be a spline
+// at all. Data in a supported layout that is truncated or damaged gives ErrCorruptData instead.
+type ErrUnsupportedVersion struct {
+	Found     int
+	Supported []int
+}
+
+func (e ErrUnsupportedVersion) Error() string {
+	found := "unknown"
+	if e.Found > 0 {
+		found = fmt.Sprintf("%d.x", e.Found)
+	}
+	supported := make([]string, len(e.Supported))
+	for i, v := range e.Supported {
+		supported[i] = fmt.Sprintf("%d.x", v)
+	}
+
+	return fmt.Sprintf("BSpline data has an unsupported format, found SPLINTER format version %s, supported: %s", found,
+		strings.Join(supported, ", "))
+}
+
+// checkFormat verifies that data holds a spline in a supported binary format before it is handed to splinter, which
+// does no validation of its own and fails cryptically (or crashes) on anything else.
+func checkFormat(data []byte) error {
+	_, _, _, err := decodeParts(data)
+	if err == ErrInvalidEncoding {
+		return ErrUnsupportedVersion{Found: 0, Supported: supportedFormatVersions}
+	}
+	return err
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, encoding the spline in splinter's binary format as written by
+// Save, so that splines can be stored as byte blobs or sent with encoding/gob. Like Save, it only keeps the spline
+// itself, not the data it was built from or the clamp of BSplineBuilder.NonNegative.
+func (bs *BSpline) MarshalBinary() ([]byte, error) {
+	return bs.saveBytes()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing the spline with the one encoded in data by
+// MarshalBinary. It works on a zero BSpline, and the decoded spline is freed by the garbage collector like one
+// returned by Build. The data is checked like in LoadBSpline.
+func (bs *BSpline) UnmarshalBinary(data []byte) error {
+	res, err := loadBSplineBytes(data)
+	if err != nil {
+		return err
+	}
+
+	bs.adopt(res)
+	return nil
+}
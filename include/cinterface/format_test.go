@@ -0,0 +1,112 @@
+package splinter
+
+import (
+	"bytes"
+	"encoding/gob"
+	"reflect"
+	"testing"
+)
+
+func TestDecodePartsRoundTrip(t *testing.T) {
+	bs := newTestSpline2D(t)
+	data, err := bs.saveBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	degrees, knotVectors, coefficients, err := decodeParts(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedKnots, err := bs.KnotVectors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedCoefficients, err := bs.GetCoefficients()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(degrees, []int{3, 3}) || !reflect.DeepEqual(knotVectors, expectedKnots) ||
+		!reflect.DeepEqual(coefficients, expectedCoefficients) {
+		t.Errorf("decoded parts differ from the spline's")
+	}
+}
+
+func TestLoadUnsupportedVersion(t *testing.T) {
+	data, err := newTestSpline1D(t).saveBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, bad := range map[string][]byte{
+		"empty":     {},
+		"text":      []byte("not a spline at all"),
+		"huge size": append([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f}, data[8:]...),
+	} {
+		_, err := loadBSplineBytes(bad)
+		version, ok := err.(ErrUnsupportedVersion)
+		if !ok {
+			t.Errorf("%s: expected ErrUnsupportedVersion, got %v", name, err)
+			continue
+		}
+		if version.Found != 0 || !reflect.DeepEqual(version.Supported, []int{3}) {
+			t.Errorf("%s: unexpected versions %+v", name, version)
+		}
+	}
+
+	// data that starts like a spline is reported as damaged rather than as another format
+	for name, bad := range map[string][]byte{
+		"truncated": data[:len(data)-4],
+		"trailing":  append(append([]byte{}, data...), 0),
+	} {
+		if _, err := loadBSplineBytes(bad); err != ErrCorruptData {
+			t.Errorf("%s: expected ErrCorruptData, got %v", name, err)
+		}
+	}
+
+	for _, c := range []struct {
+		err      ErrUnsupportedVersion
+		expected string
+	}{
+		{ErrUnsupportedVersion{Supported: []int{2, 3}},
+			"BSpline data has an unsupported format, found SPLINTER format version unknown, supported: 2.x, 3.x"},
+		{ErrUnsupportedVersion{Found: 1, Supported: []int{3}},
+			"BSpline data has an unsupported format, found SPLINTER format version 1.x, supported: 3.x"},
+	} {
+		if msg := c.err.Error(); msg != c.expected {
+			t.Errorf("expected %q, got %q", c.expected, msg)
+		}
+	}
+
+	if _, err := loadBSplineBytes(data); err != nil {
+		t.Errorf("expected a valid spline to load, got %v", err)
+	}
+}
+
+func TestMarshalBinaryGob(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(bs); err != nil {
+		t.Fatal(err)
+	}
+	decoded := &BSpline{}
+	if err := gob.NewDecoder(&buf).Decode(decoded); err != nil {
+		t.Fatal(err)
+	}
+	defer decoded.Free()
+
+	for _, p := range [][]float64{{0, 0}, {0.3, 0.7}, {1, 1}} {
+		expected, err := bs.Eval(p...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v, err := decoded.Eval(p...); err != nil || v != expected {
+			t.Errorf("point %v: expected %v, got %v, %v", p, expected, v, err)
+		}
+	}
+
+	if err := (&BSpline{}).UnmarshalBinary([]byte("garbage")); err == nil {
+		t.Error("expected an error for garbage")
+	}
+}
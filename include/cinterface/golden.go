@@ -0,0 +1,93 @@
+package splinter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// ErrGoldenMismatch is returned by AssertAgainstGrid when the spline deviates from the reference values by more than
+// the tolerance. It describes the worst deviation: the line of the reference file, the point, the expected and the
+// actual value. Failures is the number of points beyond the tolerance.
+type ErrGoldenMismatch struct {
+	Line     int
+	Point    []float64
+	Expected float64
+	Got      float64
+	Failures int
+}
+
+func (e ErrGoldenMismatch) Error() string {
+	return fmt.Sprintf("%d points deviate from the reference, worst on line %d at %v: expected %v, got %v",
+		e.Failures, e.Line, e.Point, e.Expected, e.Got)
+}
+
+// AssertAgainstGrid compares the spline with reference values read from r as CSV, one row per point holding the
+// inputs followed by the expected value, as written from an earlier, known-good fit. A first row that is not numeric
+// is taken to be a header and skipped. Every point is evaluated with Eval, and if any value deviates from the expected
+// one by more than tol, an ErrGoldenMismatch describing the worst is returned; rows that cannot be parsed or do not
+// have numVariables+1 fields fail with an ErrMalformedLine. It is meant for golden-file tests of fits.
+func (bs *BSpline) AssertAgainstGrid(r io.Reader, tol float64) error {
+	if r == nil {
+		return ErrInvalidNil
+	}
+	if !(tol >= 0) {
+		return ErrInvalidTolerance
+	}
+	n, err := bs.NumVariables()
+	if err != nil {
+		return err
+	}
+
+	var worst ErrGoldenMismatch
+	worstDiff := -1.0
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = n + 1
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return ErrMalformedLine{Line: line, Err: err}
+		}
+
+		row := make([]float64, len(record))
+		for i, field := range record {
+			row[i], err = strconv.ParseFloat(field, 64)
+			if err != nil {
+				break
+			}
+		}
+		if err != nil {
+			if line == 1 {
+				continue
+			}
+			return ErrMalformedLine{Line: line, Err: err}
+		}
+
+		got, err := bs.Eval(row[:n]...)
+		if err != nil {
+			return err
+		}
+		diff := math.Abs(got - row[n])
+		if math.IsNaN(diff) {
+			// a NaN on either side is as bad as it gets
+			diff = math.Inf(1)
+		}
+		if diff > tol {
+			worst.Failures++
+			if diff > worstDiff {
+				worst.Line, worst.Point, worst.Expected, worst.Got = line, row[:n], row[n], got
+				worstDiff = diff
+			}
+		}
+	}
+
+	if worst.Failures > 0 {
+		return worst
+	}
+	return nil
+}
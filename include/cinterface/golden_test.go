@@ -0,0 +1,45 @@
+package splinter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAssertAgainstGrid(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	golden := "x0,x1,y\n0,0,0\n0.5,0.5,0.5\n1,0.5,1.5\n"
+	if err := bs.AssertAgainstGrid(strings.NewReader(golden), 1e-9); err != nil {
+		t.Errorf("expected the reference to match, got %v", err)
+	}
+
+	// the last two rows are off, the last by more
+	golden = "0,0,0\n0.5,0.5,0.6\n1,0.5,1.2\n"
+	err := bs.AssertAgainstGrid(strings.NewReader(golden), 1e-9)
+	e, ok := err.(ErrGoldenMismatch)
+	if !ok {
+		t.Fatalf("expected ErrGoldenMismatch, got %v", err)
+	}
+	if e.Failures != 2 || e.Line != 3 || e.Expected != 1.2 || !almostEqual(e.Got, 1.5, 1e-9) {
+		t.Errorf("unexpected mismatch %+v", e)
+	}
+	if err := bs.AssertAgainstGrid(strings.NewReader(golden), 0.5); err != nil {
+		t.Errorf("expected a loose tolerance to pass, got %v", err)
+	}
+
+	for _, c := range []struct {
+		input string
+		line  int
+	}{
+		{"0,0,0\n0.5,x,0.5\n", 2},
+		{"0,0,0\n0.5,0.5\n", 2},
+	} {
+		err := bs.AssertAgainstGrid(strings.NewReader(c.input), 1e-9)
+		if e, ok := err.(ErrMalformedLine); !ok || e.Line != c.line {
+			t.Errorf("%q: expected an error on line %d, got %v", c.input, c.line, err)
+		}
+	}
+	if err := bs.AssertAgainstGrid(strings.NewReader(""), -1); err != ErrInvalidTolerance {
+		t.Errorf("expected ErrInvalidTolerance, got %v", err)
+	}
+}
@@ -0,0 +1,308 @@
+package splinter
+
+import (
+	"math"
+)
+
+// checkAxes verifies that axes describe a grid over n variables.
+func checkAxes(axes [][]float64, n int) error {
+	if len(axes) != n {
+		return ErrDimensionMismatch
+	}
+	for _, axis := range axes {
+		if len(axis) == 0 {
+			return ErrEmptyAxis
+		}
+	}
+	return nil
+}
+
+// gridSize returns the number of points in the Cartesian product of axes.
+func gridSize(axes [][]float64) int {
+	size := 1
+	for _, axis := range axes {
+		size *= len(axis)
+	}
+	return size
+}
+
+// gridPoint writes the k-th point of the Cartesian product of axes into point. The last axis varies fastest, which is
+// the same ordering splinter uses for the tensor product coefficients.
+func gridPoint(axes [][]float64, k int, point []float64) {
+	for i := len(axes) - 1; i >= 0; i-- {
+		n := len(axes[i])
+		point[i] = axes[i][k%n]
+		k /= n
+	}
+}
+
+// maxDomainGridValues bounds the number of coordinates, points times variables, of a grid built by domainGrid, which
+// grows like gridPerDim^numVariables: 1<<24 takes 128 MiB.
+const maxDomainGridValues = 1 << 24
+
+// domainGrid returns the points of a grid of gridPerDim evenly spaced values per variable spanning the domain of the
+// spline, in EvalGrid order and flattened row-major, and their number. Grids of more than maxDomainGridValues
+// coordinates are rejected with ErrInvalidCount.
+func (bs *BSpline) domainGrid(gridPerDim int) (flat []float64, size int, err error) {
+	if gridPerDim < 2 {
+		return nil, 0, ErrInvalidCount
+	}
+
+	domain, err := bs.GetDomain()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// checked one variable at a time, before the product can overflow
+	n := len(domain)
+	size = 1
+	for range domain {
+		if size > maxDomainGridValues/n/gridPerDim {
+			return nil, 0, ErrInvalidCount
+		}
+		size *= gridPerDim
+	}
+
+	axes := make([][]float64, n)
+	for i, bounds := range domain {
+		axes[i] = linspace(bounds[0], bounds[1], gridPerDim)
+	}
+
+	flat = make([]float64, size*n)
+	for k := 0; k < size; k++ {
+		gridPoint(axes, k, flat[k*n:(k+1)*n])
+	}
+	return flat, size, nil
+}
+
+// EvalGrid evaluates the spline on the Cartesian product of the given axes, where axes[i] holds the values of
+// variable i. The results are ordered with the last axis varying fastest.
+func (bs *BSpline) EvalGrid(axes [][]float64) ([]float64, error) {
+	n, err := bs.NumVariables()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkAxes(axes, n); err != nil {
+		return nil, err
+	}
+
+	size := gridSize(axes)
+	flat := make([]float64, size*n)
+	for k := 0; k < size; k++ {
+		gridPoint(axes, k, flat[k*n:(k+1)*n])
+	}
+
+	return bs.evalRowMajor(flat, size)
+}
+
+// Grid holds the values of a spline on the Cartesian product of axes, see EvalGridND. Values is ordered like EvalGrid,
+// with the last axis varying fastest, and Shape holds the length of each axis.
+type Grid struct {
+	Values []float64
+	Shape  []int
+}
+
+// At returns the value at the grid point whose coordinate along axis i is axes[i][indices[i]]. Like indexing a slice,
+// it panics if the number of indices differs from len(Shape) or an index is out of range.
+func (g *Grid) At(indices ...int) float64 {
+	if len(indices) != len(g.Shape) {
+		panic("splinter: wrong number of grid indices")
+	}
+
+	k := 0
+	for i, idx := range indices {
+		if idx < 0 || idx >= g.Shape[i] {
+			panic("splinter: grid index out of range")
+		}
+		k = k*g.Shape[i] + idx
+	}
+	return g.Values[k]
+}
+
+// EvalGridND evaluates the spline on the Cartesian product of the given axes like EvalGrid, returning the values
+// together with the shape of the grid so they can be indexed by axis position with Grid.At.
+func (bs *BSpline) EvalGridND(axes [][]float64) (*Grid, error) {
+	values, err := bs.EvalGrid(axes)
+	if err != nil {
+		return nil, err
+	}
+
+	shape := make([]int, len(axes))
+	for i, axis := range axes {
+		shape[i] = len(axis)
+	}
+	return &Grid{Values: values, Shape: shape}, nil
+}
+
+// EvalBroadcast evaluates the spline over axes like NumPy broadcasting: axes[i] holds the values of variable i, axes of
+// length 1 hold their variable constant, and the result covers the Cartesian product of the longer axes, with the last
+// of them varying fastest. For example, axes {{0, 1}, {0.5}, {2, 3, 4}} give the 6 values at (0, 0.5, 2), (0, 0.5, 3),
+// (0, 0.5, 4), (1, 0.5, 2) and so on. Since constant axes do not affect the ordering, this is the same as EvalGrid.
+func (bs *BSpline) EvalBroadcast(axes [][]float64) ([]float64, error) {
+	return bs.EvalGrid(axes)
+}
+
+// GradientGrid evaluates the gradient of the spline on the Cartesian product of the given axes, for quiver plots and
+// the like. It returns the grid points in EvalGrid order and the gradient at each, in a single call into splinter.
+func (bs *BSpline) GradientGrid(axes [][]float64) (points [][]float64, grads [][]float64, err error) {
+	n, err := bs.NumVariables()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkAxes(axes, n); err != nil {
+		return nil, nil, err
+	}
+
+	size := gridSize(axes)
+	flat := make([]float64, size*n)
+	points = make([][]float64, size)
+	for k := range points {
+		points[k] = flat[k*n : (k+1)*n : (k+1)*n]
+		gridPoint(axes, k, points[k])
+	}
+
+	jacobians, err := bs.evalJacobianRowMajor(flat, size)
+	if err != nil {
+		return nil, nil, err
+	}
+	grads = make([][]float64, size)
+	for k := range grads {
+		grads[k] = jacobians[k*n : (k+1)*n : (k+1)*n]
+	}
+	return points, grads, nil
+}
+
+// gridStreamChunk is the number of grid points EvalGridStream evaluates per call into splinter.
+const gridStreamChunk = 4096
+
+// EvalGridStream evaluates the spline on the Cartesian product of the given axes like EvalGrid, but passes each point
+// and value to fn in the same order instead of collecting them, so memory use does not grow with the grid. Points
+// are evaluated in chunks of gridStreamChunk. The point slice is reused between calls and must not be retained by
+// fn. Iteration stops at the first error returned by fn, which is returned.
+func (bs *BSpline) EvalGridStream(axes [][]float64, fn func(point []float64, value float64) error) error {
+	if fn == nil {
+		return ErrInvalidNil
+	}
+
+	n, err := bs.NumVariables()
+	if err != nil {
+		return err
+	}
+	if err := checkAxes(axes, n); err != nil {
+		return err
+	}
+
+	size := gridSize(axes)
+	flat := make([]float64, gridStreamChunk*n)
+	point := make([]float64, n)
+	for start := 0; start < size; start += gridStreamChunk {
+		count := size - start
+		if count > gridStreamChunk {
+			count = gridStreamChunk
+		}
+		for k := 0; k < count; k++ {
+			gridPoint(axes, start+k, flat[k*n:(k+1)*n])
+		}
+
+		values, err := bs.evalRowMajor(flat[:count*n], count)
+		if err != nil {
+			return err
+		}
+		for k, v := range values {
+			copy(point, flat[k*n:(k+1)*n])
+			if err := fn(point, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MaxErrorVsFunc compares the spline with the function f it approximates on the Cartesian product of the given axes,
+// returning the largest absolute difference and the point where it occurs (the first such point in EvalGrid order).
+// The grid is streamed with EvalGridStream, so it can be arbitrarily large. f is called once per point and must not
+// retain its argument.
+func (bs *BSpline) MaxErrorVsFunc(f func([]float64) float64, axes [][]float64) (maxErr float64, at []float64, err error) {
+	if f == nil {
+		return 0, nil, ErrInvalidNil
+	}
+
+	err = bs.EvalGridStream(axes, func(point []float64, value float64) error {
+		diff := math.Abs(value - f(point))
+		if at == nil || diff > maxErr {
+			maxErr = diff
+			at = append(at[:0], point...)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return maxErr, at, nil
+}
+
+// maxCornerVariables bounds the number of variables CornerValues accepts, as the number of corners doubles with each.
+const maxCornerVariables = 16
+
+// CornerValues evaluates the spline at the 2^n corners of its domain, enumerated like EvalGrid with each axis holding
+// the lower and upper bound of a variable. Splines with more than maxCornerVariables variables are rejected with
+// ErrUnsupportedDim.
+func (bs *BSpline) CornerValues() (corners [][]float64, values []float64, err error) {
+	domain, err := bs.GetDomain()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(domain) > maxCornerVariables {
+		return nil, nil, ErrUnsupportedDim
+	}
+
+	corners = make([][]float64, gridSize(domain))
+	for k := range corners {
+		corners[k] = make([]float64, len(domain))
+		gridPoint(domain, k, corners[k])
+	}
+
+	values, err = bs.evalSamples(corners)
+	if err != nil {
+		return nil, nil, err
+	}
+	return corners, values, nil
+}
+
+// Resample evaluates the spline on the Cartesian product of the given axes (see EvalGrid) and returns a new DataTable
+// holding the grid points and the corresponding predictions.
+func (bs *BSpline) Resample(axes [][]float64) (*DataTable, error) {
+	values, err := bs.EvalGrid(axes)
+	if err != nil {
+		return nil, err
+	}
+
+	// one column per variable, followed by the predictions
+	columns := make([][]float64, len(axes)+1)
+	for i := range axes {
+		columns[i] = make([]float64, len(values))
+	}
+	columns[len(axes)] = values
+
+	point := make([]float64, len(axes))
+	for k := range values {
+		gridPoint(axes, k, point)
+		for i, x := range point {
+			columns[i][k] = x
+		}
+	}
+
+	dt, err := NewDataTable()
+	if err != nil {
+		return nil, err
+	}
+
+	err = dt.AddColumns(columns...)
+	if err != nil {
+		dt.Free()
+		return nil, err
+	}
+
+	return dt, nil
+}
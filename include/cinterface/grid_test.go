@@ -0,0 +1,249 @@
+package splinter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEvalGrid(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	axes := [][]float64{{0.1, 0.5}, {0.2, 0.4, 0.9}}
+	values, err := bs.EvalGrid(axes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 6 {
+		t.Fatalf("expected 6 values, got %d", len(values))
+	}
+
+	point := make([]float64, 2)
+	for k, v := range values {
+		gridPoint(axes, k, point)
+		if !almostEqual(v, bilinearish(point), 1e-9) {
+			t.Errorf("at %v: expected %v, got %v", point, bilinearish(point), v)
+		}
+	}
+
+	if _, err := bs.EvalGrid(axes[:1]); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := bs.EvalGrid([][]float64{{0.1}, {}}); err != ErrEmptyAxis {
+		t.Errorf("expected ErrEmptyAxis, got %v", err)
+	}
+}
+
+func TestEvalGridStream(t *testing.T) {
+	bs := newTestSpline2D(t)
+	axes := [][]float64{linspace(0, 1, 101), linspace(0, 1, 51)}
+
+	expected, err := bs.EvalGrid(axes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	k := 0
+	err = bs.EvalGridStream(axes, func(point []float64, value float64) error {
+		if value != expected[k] {
+			t.Fatalf("point %d %v: expected %v, got %v", k, point, expected[k], value)
+		}
+		k++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k != len(expected) {
+		t.Errorf("expected %d points, got %d", len(expected), k)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = bs.EvalGridStream(axes, func(point []float64, value float64) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("expected iteration to stop at the first error, got %v after %d calls", err, calls)
+	}
+}
+
+func TestMaxErrorVsFunc(t *testing.T) {
+	bs := newTestSpline2D(t)
+	axes := [][]float64{linspace(0, 1, 21), linspace(0, 1, 21)}
+
+	maxErr, _, err := bs.MaxErrorVsFunc(bilinearish, axes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if maxErr > 1e-9 {
+		t.Errorf("expected the spline to match its reference, got max error %v", maxErr)
+	}
+
+	// a reference with a bump the spline does not have
+	bumped := func(x []float64) float64 {
+		if x[0] == 0.5 && x[1] == 0.25 {
+			return bilinearish(x) + 0.1
+		}
+		return bilinearish(x)
+	}
+	maxErr, at, err := bs.MaxErrorVsFunc(bumped, axes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual(maxErr, 0.1, 1e-9) || at[0] != 0.5 || at[1] != 0.25 {
+		t.Errorf("expected an error of 0.1 at [0.5 0.25], got %v at %v", maxErr, at)
+	}
+}
+
+func TestCornerValues(t *testing.T) {
+	corners, values, err := newTestSpline2D(t).CornerValues()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := [][]float64{{0, 0}, {0, 1}, {1, 0}, {1, 1}}
+	if !reflect.DeepEqual(corners, expected) {
+		t.Fatalf("expected corners %v, got %v", expected, corners)
+	}
+	for i, corner := range corners {
+		if !almostEqual(values[i], bilinearish(corner), 1e-9) {
+			t.Errorf("at %v: expected %v, got %v", corner, bilinearish(corner), values[i])
+		}
+	}
+}
+
+func TestResample(t *testing.T) {
+	bs := newTestSpline1D(t)
+
+	dt, err := bs.Resample([][]float64{linspace(0, 2, 41)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	builder, err := NewBSplineBuilder(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	refit, err := builder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, x := range []float64{0.3, 1.1, 1.9} {
+		v, err := refit.Eval(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !almostEqual(v, x*x, 1e-9) {
+			t.Errorf("at %v: expected %v, got %v", x, x*x, v)
+		}
+	}
+}
+
+func TestGradientGrid(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	points, grads, err := bs.GradientGrid([][]float64{{0.25, 0.5}, {0, 0.5, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 6 || len(grads) != 6 {
+		t.Fatalf("expected 6 points and gradients, got %d and %d", len(points), len(grads))
+	}
+	if points[4][0] != 0.5 || points[4][1] != 0.5 {
+		t.Errorf("expected the fifth point to be (0.5, 0.5), got %v", points[4])
+	}
+	for k, p := range points {
+		// the gradient of x0² + x0*x1 is (2*x0 + x1, x0)
+		if !almostEqual(grads[k][0], 2*p[0]+p[1], 1e-9) || !almostEqual(grads[k][1], p[0], 1e-9) {
+			t.Errorf("at %v: expected (%v, %v), got %v", p, 2*p[0]+p[1], p[0], grads[k])
+		}
+	}
+
+	if _, _, err := bs.GradientGrid([][]float64{{0.5}}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
+func TestEvalBroadcast(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	values, err := bs.EvalBroadcast([][]float64{{0.5}, {0, 0.25, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []float64{0.25, 0.375, 0.75}
+	if len(values) != len(expected) {
+		t.Fatalf("expected %d values, got %d", len(expected), len(values))
+	}
+	for i, v := range values {
+		if !almostEqual(v, expected[i], 1e-9) {
+			t.Errorf("value %d: expected %v, got %v", i, expected[i], v)
+		}
+	}
+
+	if _, err := bs.EvalBroadcast([][]float64{{0.5}}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := bs.EvalBroadcast([][]float64{{0.5}, {}}); err != ErrEmptyAxis {
+		t.Errorf("expected ErrEmptyAxis, got %v", err)
+	}
+}
+
+func TestEvalGridND(t *testing.T) {
+	bs := newTestSpline2D(t)
+	axes := [][]float64{{0, 0.5, 1}, {0.25, 0.75}}
+
+	grid, err := bs.EvalGridND(axes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(grid.Shape, []int{3, 2}) {
+		t.Fatalf("expected shape [3 2], got %v", grid.Shape)
+	}
+	for i, x0 := range axes[0] {
+		for j, x1 := range axes[1] {
+			expected := bilinearish([]float64{x0, x1})
+			if v := grid.At(i, j); !almostEqual(v, expected, 1e-9) {
+				t.Errorf("At(%d, %d): expected %v, got %v", i, j, expected, v)
+			}
+		}
+	}
+
+	for _, indices := range [][]int{{0}, {3, 0}, {0, -1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("At%v: expected a panic", indices)
+				}
+			}()
+			grid.At(indices...)
+		}()
+	}
+
+	if _, err := bs.EvalGridND([][]float64{{0}}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
+func TestDomainGridLimit(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	flat, size, err := bs.domainGrid(11)
+	if err != nil || size != 121 || len(flat) != 242 {
+		t.Errorf("expected 121 points, got %d (%d values), %v", size, len(flat), err)
+	}
+
+	// 4096^2 points of 2 coordinates are over the limit, and the largest int per variable would overflow the count
+	maxInt := int(^uint(0) >> 1)
+	for _, gridPerDim := range []int{4096, maxInt} {
+		if _, _, err := bs.domainGrid(gridPerDim); err != ErrInvalidCount {
+			t.Errorf("%d per variable: expected ErrInvalidCount, got %v", gridPerDim, err)
+		}
+	}
+	if _, err := bs.MinValue(maxInt); err != ErrInvalidCount {
+		t.Errorf("expected MinValue to reject the grid, got %v", err)
+	}
+}
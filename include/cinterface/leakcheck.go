@@ -0,0 +1,27 @@
+//go:build splinterleaks
+// +build splinterleaks
+
+package splinter
+
+import (
+	"sync/atomic"
+)
+
+// liveObjects counts the DataTables, BSplineBuilders and BSplines created but not yet freed.
+var liveObjects int64
+
+func trackCreated() {
+	atomic.AddInt64(&liveObjects, 1)
+}
+
+func trackFreed() {
+	atomic.AddInt64(&liveObjects, -1)
+}
+
+// LiveObjectCount returns the number of DataTables, BSplineBuilders and BSplines that have been created and not freed
+// with Free. Objects only reclaimed by their finalizer still count as live, so a test can assert the count returns to
+// its starting value to catch missing calls to Free. It is only available when building with the splinterleaks tag,
+// so that other builds pay nothing for the bookkeeping.
+func LiveObjectCount() int {
+	return int(atomic.LoadInt64(&liveObjects))
+}
@@ -0,0 +1,55 @@
+//go:build splinterleaks
+// +build splinterleaks
+
+package splinter
+
+import (
+	"testing"
+)
+
+func TestLiveObjectCount(t *testing.T) {
+	start := LiveObjectCount()
+
+	dt, err := NewDataTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dt.AddColumns(linspace(0, 2, 11), linspace(0, 4, 11)); err != nil {
+		t.Fatal(err)
+	}
+	builder, err := NewBSplineBuilder(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs, err := builder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	clone, err := bs.clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := LiveObjectCount() - start; n != 4 {
+		t.Errorf("expected 4 live objects, got %d", n)
+	}
+
+	// adopting frees the spline that is replaced
+	bs.adopt(clone)
+	if n := LiveObjectCount() - start; n != 3 {
+		t.Errorf("expected 3 live objects after adopt, got %d", n)
+	}
+
+	bs.Free()
+	builder.Free()
+	dt.Free()
+	if n := LiveObjectCount() - start; n != 0 {
+		t.Errorf("expected no live objects after freeing everything, got %d", n)
+	}
+	// freeing again must not count the objects a second time
+	bs.Free()
+	builder.Free()
+	dt.Free()
+	if n := LiveObjectCount() - start; n != 0 {
+		t.Errorf("expected no live objects after freeing twice, got %d", n)
+	}
+}
@@ -0,0 +1,46 @@
+package splinter
+
+import (
+	"sync/atomic"
+)
+
+var (
+	// nativeObjects counts the DataTables, BSplineBuilders and BSplines holding a splinter object. Unlike the count of
+	// LiveObjectCount, objects reclaimed by their finalizer are subtracted too.
+	nativeObjects int64
+
+	// maxNativeObjects is the limit set by SetMaxLiveObjects, 0 for none.
+	maxNativeObjects int64
+)
+
+// SetMaxLiveObjects limits the number of DataTables, BSplineBuilders and BSplines that may hold splinter objects at
+// the same time to n, as a guardrail against leaks or bursts exhausting native memory in a shared process. Once the
+// limit is reached, constructors (NewDataTable, NewBSplineBuilder, Build, and everything returning a new spline) fail
+// with ErrTooManyObjects until objects are released with Free or reclaimed by the garbage collector. Objects that
+// already exist are not affected by lowering the limit. A limit n <= 0 removes it, which is the default.
+func SetMaxLiveObjects(n int) {
+	if n < 0 {
+		n = 0
+	}
+	atomic.StoreInt64(&maxNativeObjects, int64(n))
+}
+
+// reserveObject counts a new splinter object, failing with ErrTooManyObjects if that would exceed the limit set by
+// SetMaxLiveObjects.
+func reserveObject() error {
+	for {
+		count := atomic.LoadInt64(&nativeObjects)
+		max := atomic.LoadInt64(&maxNativeObjects)
+		if max > 0 && count >= max {
+			return ErrTooManyObjects
+		}
+		if atomic.CompareAndSwapInt64(&nativeObjects, count, count+1) {
+			return nil
+		}
+	}
+}
+
+// releaseObject uncounts a splinter object that was deleted.
+func releaseObject() {
+	atomic.AddInt64(&nativeObjects, -1)
+}
@@ -0,0 +1,43 @@
+package splinter
+
+import (
+	"testing"
+)
+
+func TestSetMaxLiveObjects(t *testing.T) {
+	defer SetMaxLiveObjects(0)
+
+	bs := newTestSpline1D(t)
+	dt, err := bs.Resample([][]float64{linspace(0, 2, 11)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	builder, err := NewBSplineBuilder(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the objects above are live, so a limit of 1 leaves no room
+	SetMaxLiveObjects(1)
+	if _, err := builder.Build(); err != ErrTooManyObjects {
+		t.Errorf("expected ErrTooManyObjects from Build, got %v", err)
+	}
+	if _, err := NewDataTable(); err != ErrTooManyObjects {
+		t.Errorf("expected ErrTooManyObjects from NewDataTable, got %v", err)
+	}
+	if _, err := NewBSplineBuilder(dt); err != ErrTooManyObjects {
+		t.Errorf("expected ErrTooManyObjects from NewBSplineBuilder, got %v", err)
+	}
+	if _, err := bs.clone(); err != ErrTooManyObjects {
+		t.Errorf("expected ErrTooManyObjects from a copy, got %v", err)
+	}
+
+	SetMaxLiveObjects(0)
+	refit, err := builder.Build()
+	if err != nil {
+		t.Fatalf("expected no limit, got %v", err)
+	}
+	refit.Free()
+	builder.Free()
+	dt.Free()
+}
@@ -0,0 +1,127 @@
+package splinter
+
+import (
+	"math"
+)
+
+// Small dense linear algebra helpers for the diagnostics computed on the Go side. Matrices are row major [][]float64.
+
+// newMatrix returns a zeroed rows x cols matrix.
+func newMatrix(rows, cols int) [][]float64 {
+	flat := make([]float64, rows*cols)
+	m := make([][]float64, rows)
+	for i := range m {
+		m[i] = flat[i*cols : (i+1)*cols]
+	}
+	return m
+}
+
+// cholesky returns the lower triangular L with a = L Lᵀ, or ok=false if a is not (numerically) positive definite.
+func cholesky(a [][]float64) (l [][]float64, ok bool) {
+	n := len(a)
+	l = newMatrix(n, n)
+	for j := 0; j < n; j++ {
+		sum := a[j][j]
+		for k := 0; k < j; k++ {
+			sum -= l[j][k] * l[j][k]
+		}
+		if sum <= 0 {
+			return nil, false
+		}
+		l[j][j] = math.Sqrt(sum)
+
+		for i := j + 1; i < n; i++ {
+			sum := a[i][j]
+			for k := 0; k < j; k++ {
+				sum -= l[i][k] * l[j][k]
+			}
+			l[i][j] = sum / l[j][j]
+		}
+	}
+	return l, true
+}
+
+// choleskySolve solves L Lᵀ x = b for x.
+func choleskySolve(l [][]float64, b []float64) []float64 {
+	n := len(l)
+	x := make([]float64, n)
+	for i := 0; i < n; i++ {
+		sum := b[i]
+		for k := 0; k < i; k++ {
+			sum -= l[i][k] * x[k]
+		}
+		x[i] = sum / l[i][i]
+	}
+	for i := n - 1; i >= 0; i-- {
+		sum := x[i]
+		for k := i + 1; k < n; k++ {
+			sum -= l[k][i] * x[k]
+		}
+		x[i] = sum / l[i][i]
+	}
+	return x
+}
+
+func mulVec(a [][]float64, x []float64) []float64 {
+	res := make([]float64, len(a))
+	for i, row := range a {
+		for j, v := range row {
+			res[i] += v * x[j]
+		}
+	}
+	return res
+}
+
+func dot(a, b []float64) float64 {
+	res := 0.0
+	for i := range a {
+		res += a[i] * b[i]
+	}
+	return res
+}
+
+// powerIterations bounds the number of iterations used to estimate extreme eigenvalues.
+const powerIterations = 1000
+
+// dominantEigenvalue estimates the largest eigenvalue of the symmetric positive semi-definite operator apply by power
+// iteration.
+func dominantEigenvalue(n int, apply func(x []float64) []float64) float64 {
+	// a deterministic start vector that is unlikely to be orthogonal to the dominant eigenvector
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = 1 + float64(i%7)*1e-3
+	}
+
+	lambda := 0.0
+	for iter := 0; iter < powerIterations; iter++ {
+		norm := math.Sqrt(dot(x, x))
+		if norm == 0 {
+			return 0
+		}
+		for i := range x {
+			x[i] /= norm
+		}
+
+		y := apply(x)
+		next := dot(x, y)
+		x = y
+		if iter > 0 && math.Abs(next-lambda) <= 1e-12*math.Abs(next) {
+			return next
+		}
+		lambda = next
+	}
+	return lambda
+}
+
+// conditionNumber estimates the 2-norm condition number of the symmetric positive semi-definite matrix a as the ratio
+// of its extreme eigenvalues. Singular matrices have an infinite condition number.
+func conditionNumber(a [][]float64) float64 {
+	l, ok := cholesky(a)
+	if !ok {
+		return math.Inf(1)
+	}
+
+	lambdaMax := dominantEigenvalue(len(a), func(x []float64) []float64 { return mulVec(a, x) })
+	invLambdaMin := dominantEigenvalue(len(a), func(x []float64) []float64 { return choleskySolve(l, x) })
+	return lambdaMax * invLambdaMin
+}
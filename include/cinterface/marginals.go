@@ -0,0 +1,84 @@
+package splinter
+
+import (
+	"sort"
+)
+
+// projectSamples returns the samples projected onto input variable dim, with the responses of samples sharing a value
+// of that variable averaged, sorted by the value.
+func projectSamples(x [][]float64, y []float64, dim int) ([][]float64, []float64) {
+	order := make([]int, len(y))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return x[order[a]][dim] < x[order[b]][dim] })
+
+	var px [][]float64
+	var py []float64
+	count := 0
+	for _, i := range order {
+		v := x[i][dim]
+		if len(px) > 0 && px[len(px)-1][0] == v {
+			count++
+			py[len(py)-1] += (y[i] - py[len(py)-1]) / float64(count)
+			continue
+		}
+
+		px = append(px, []float64{v})
+		py = append(py, y[i])
+		count = 1
+	}
+	return px, py
+}
+
+// FitMarginals fits one univariate spline per input variable of dt, describing the response as a function of that
+// variable alone, as in an additive model. Each is fitted with cfg to the samples projected onto its variable, where
+// the responses of samples sharing a value are averaged over the other variables. Per-variable settings in cfg
+// (Bounds, Degrees and NumBasisFunctions) are split between the marginals; weights refer to the full samples and are
+// not used.
+func FitMarginals(dt *DataTable, cfg BuilderConfig) ([]*BSpline, error) {
+	if dt == nil {
+		return nil, ErrInvalidNil
+	}
+	if len(dt.y) == 0 {
+		return nil, ErrNoSamples
+	}
+
+	dim := dt.numVariables()
+	marginals := make([]*BSpline, 0, dim)
+	free := func() {
+		for _, bs := range marginals {
+			bs.Free()
+		}
+	}
+
+	for i := 0; i < dim; i++ {
+		marginalCfg := cfg
+		marginalCfg.Weights = nil
+		if len(cfg.Bounds) == dim {
+			marginalCfg.Bounds = cfg.Bounds[i : i+1]
+		}
+		if len(cfg.Degrees) == dim {
+			marginalCfg.Degrees = cfg.Degrees[i : i+1]
+		}
+		if len(cfg.NumBasisFunctions) == dim {
+			marginalCfg.NumBasisFunctions = cfg.NumBasisFunctions[i : i+1]
+		}
+
+		x, y := projectSamples(dt.x, dt.y, i)
+		table, err := newDataTableFromSamples(x, y)
+		if err != nil {
+			free()
+			return nil, err
+		}
+
+		bs, err := Fit(table, marginalCfg)
+		table.Free()
+		if err != nil {
+			free()
+			return nil, err
+		}
+		marginals = append(marginals, bs)
+	}
+	return marginals, nil
+}
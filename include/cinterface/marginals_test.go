@@ -0,0 +1,42 @@
+package splinter
+
+import (
+	"testing"
+)
+
+func TestFitMarginals(t *testing.T) {
+	// x0^2 + x1 on an 11x11 grid over the unit square, where the mean of x0^2 over the grid is 0.35 and that of x1
+	// is 0.5
+	additive := func(x []float64) float64 { return x[0]*x[0] + x[1] }
+	dt, err := buildTestSpline(t, [][]float64{linspace(0, 1, 11), linspace(0, 1, 11)}, additive).
+		Resample([][]float64{linspace(0, 1, 11), linspace(0, 1, 11)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	marginals, err := FitMarginals(dt, BuilderConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(marginals) != 2 {
+		t.Fatalf("expected 2 marginals, got %d", len(marginals))
+	}
+
+	for _, x := range []float64{0.25, 0.6} {
+		v, err := marginals[0].Eval(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !almostEqual(v, x*x+0.5, 1e-9) {
+			t.Errorf("marginal 0 at %v: expected %v, got %v", x, x*x+0.5, v)
+		}
+
+		v, err = marginals[1].Eval(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !almostEqual(v, x+0.35, 1e-9) {
+			t.Errorf("marginal 1 at %v: expected %v, got %v", x, x+0.35, v)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package msgpackio
+
+import (
+	"encoding/binary"
+	"math"
+
+	splinter "github.com/bgrimstad/splinter/include/cinterface"
+)
+
+// maxSkipDepth bounds the nesting of the arrays and maps that skip reads past, so that hostile input cannot exhaust the
+// stack.
+const maxSkipDepth = 32
+
+type msgpackWriter struct {
+	buf []byte
+}
+
+func (w *msgpackWriter) header(n int, fix, code16, code32 byte) {
+	switch {
+	case n < 16:
+		w.buf = append(w.buf, fix|byte(n))
+	case n <= math.MaxUint16:
+		w.buf = append(w.buf, code16, byte(n>>8), byte(n))
+	default:
+		w.buf = append(w.buf, code32, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+}
+
+func (w *msgpackWriter) mapHeader(n int) {
+	w.header(n, 0x80, 0xde, 0xdf)
+}
+
+func (w *msgpackWriter) arrayHeader(n int) {
+	w.header(n, 0x90, 0xdc, 0xdd)
+}
+
+// str writes a string of fewer than 32 bytes, which is all the keys need.
+func (w *msgpackWriter) str(s string) {
+	w.buf = append(w.buf, 0xa0|byte(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+func (w *msgpackWriter) uint(v uint64) {
+	if v < 128 {
+		w.buf = append(w.buf, byte(v))
+		return
+	}
+	w.buf = append(w.buf, 0xcf, 0, 0, 0, 0, 0, 0, 0, 0)
+	binary.BigEndian.PutUint64(w.buf[len(w.buf)-8:], v)
+}
+
+func (w *msgpackWriter) bool(v bool) {
+	if v {
+		w.buf = append(w.buf, 0xc3)
+	} else {
+		w.buf = append(w.buf, 0xc2)
+	}
+}
+
+func (w *msgpackWriter) float(v float64) {
+	w.buf = append(w.buf, 0xcb, 0, 0, 0, 0, 0, 0, 0, 0)
+	binary.BigEndian.PutUint64(w.buf[len(w.buf)-8:], math.Float64bits(v))
+}
+
+func (w *msgpackWriter) floats(values []float64) {
+	w.arrayHeader(len(values))
+	for _, v := range values {
+		w.float(v)
+	}
+}
+
+type msgpackReader struct {
+	data []byte
+	pos  int
+}
+
+// next returns the next n bytes.
+func (r *msgpackReader) next(n int) ([]byte, error) {
+	if n < 0 || len(r.data)-r.pos < n {
+		return nil, splinter.ErrInvalidEncoding
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b, nil
+}
+
+// uintN reads a big-endian unsigned integer of n bytes.
+func (r *msgpackReader) uintN(n int) (uint64, error) {
+	b, err := r.next(n)
+	if err != nil {
+		return 0, err
+	}
+	v := uint64(0)
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v, nil
+}
+
+// header reads the length of a map or array with the given fix prefix (stored in the lower 4 bits) and 16 and 32 bit
+// type codes.
+func (r *msgpackReader) header(fix, code16, code32 byte) (int, error) {
+	b, err := r.next(1)
+	if err != nil {
+		return 0, err
+	}
+
+	var n uint64
+	switch {
+	case b[0]&0xf0 == fix:
+		n = uint64(b[0] & 0x0f)
+	case b[0] == code16:
+		n, err = r.uintN(2)
+	case b[0] == code32:
+		n, err = r.uintN(4)
+	default:
+		return 0, splinter.ErrInvalidEncoding
+	}
+	if err != nil {
+		return 0, err
+	}
+	// every element takes at least a byte, which guards the allocations of the callers
+	if n > uint64(len(r.data)-r.pos) {
+		return 0, splinter.ErrInvalidEncoding
+	}
+	return int(n), nil
+}
+
+func (r *msgpackReader) mapHeader() (int, error) {
+	return r.header(0x80, 0xde, 0xdf)
+}
+
+func (r *msgpackReader) arrayHeader() (int, error) {
+	return r.header(0x90, 0xdc, 0xdd)
+}
+
+func (r *msgpackReader) str() (string, error) {
+	b, err := r.next(1)
+	if err != nil {
+		return "", err
+	}
+
+	var n uint64
+	switch {
+	case b[0]&0xe0 == 0xa0:
+		n = uint64(b[0] & 0x1f)
+	case b[0] == 0xd9:
+		n, err = r.uintN(1)
+	case b[0] == 0xda:
+		n, err = r.uintN(2)
+	case b[0] == 0xdb:
+		n, err = r.uintN(4)
+	default:
+		return "", splinter.ErrInvalidEncoding
+	}
+	if err != nil {
+		return "", err
+	}
+
+	s, err := r.next(int(n))
+	return string(s), err
+}
+
+func (r *msgpackReader) bool() (bool, error) {
+	b, err := r.next(1)
+	if err != nil {
+		return false, err
+	}
+
+	switch b[0] {
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	}
+	return false, splinter.ErrInvalidEncoding
+}
+
+// number reads an integer or float as a float64.
+func (r *msgpackReader) number() (float64, error) {
+	b, err := r.next(1)
+	if err != nil {
+		return 0, err
+	}
+
+	c := b[0]
+	switch {
+	case c < 0x80:
+		return float64(c), nil
+	case c >= 0xe0:
+		return float64(int8(c)), nil
+	case c >= 0xcc && c <= 0xcf:
+		v, err := r.uintN(1 << (c - 0xcc))
+		return float64(v), err
+	case c >= 0xd0 && c <= 0xd3:
+		n := 1 << (c - 0xd0)
+		v, err := r.uintN(n)
+		// sign extend
+		shift := uint(64 - 8*n)
+		return float64(int64(v<<shift) >> shift), err
+	case c == 0xca:
+		v, err := r.uintN(4)
+		return float64(math.Float32frombits(uint32(v))), err
+	case c == 0xcb:
+		v, err := r.uintN(8)
+		return math.Float64frombits(v), err
+	}
+	return 0, splinter.ErrInvalidEncoding
+}
+
+func (r *msgpackReader) floats() ([]float64, error) {
+	n, err := r.arrayHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]float64, n)
+	for i := range values {
+		values[i], err = r.number()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// skip reads past the next value, which may be nil, a bool, a number, a string or binary, or an array or map of
+// those. depth is the number of arrays and maps the value is nested in; beyond maxSkipDepth, ErrInvalidEncoding is
+// returned.
+func (r *msgpackReader) skip(depth int) error {
+	if r.pos >= len(r.data) || depth > maxSkipDepth {
+		return splinter.ErrInvalidEncoding
+	}
+
+	c := r.data[r.pos]
+	switch {
+	case c == 0xc0 || c == 0xc2 || c == 0xc3:
+		r.pos++
+		return nil
+	case c&0xe0 == 0xa0 || (c >= 0xd9 && c <= 0xdb):
+		_, err := r.str()
+		return err
+	case c >= 0xc4 && c <= 0xc6:
+		r.pos++
+		n, err := r.uintN(1 << (c - 0xc4))
+		if err != nil {
+			return err
+		}
+		_, err = r.next(int(n))
+		return err
+	case c&0xf0 == 0x90 || c == 0xdc || c == 0xdd:
+		n, err := r.arrayHeader()
+		for i := 0; i < n && err == nil; i++ {
+			err = r.skip(depth + 1)
+		}
+		return err
+	case c&0xf0 == 0x80 || c == 0xde || c == 0xdf:
+		n, err := r.mapHeader()
+		for i := 0; i < 2*n && err == nil; i++ {
+			err = r.skip(depth + 1)
+		}
+		return err
+	}
+	_, err := r.number()
+	return err
+}
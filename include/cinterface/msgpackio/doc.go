@@ -0,0 +1,7 @@
+// Package msgpackio encodes splines in MessagePack, for services that exchange data in it.
+//
+// The encoding of a spline is a map with the keys "degrees" (array of ints), "knots" (array of arrays of floats, one
+// per variable) and "coefficients" (array of floats), plus "nonNegative" (true) for splines that clamp negative values,
+// see splinter.BSpline.SetNonNegative. The subset of MessagePack needed for it is implemented here, so the package
+// does not depend on a MessagePack library; it is kept out of the splinter package, which has no use for it.
+package msgpackio
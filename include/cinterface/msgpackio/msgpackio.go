@@ -0,0 +1,220 @@
+package msgpackio
+
+import (
+	"math"
+
+	splinter "github.com/bgrimstad/splinter/include/cinterface"
+)
+
+// BSpline is a splinter.BSpline with MessagePack encoding methods. The embedded spline is still owned, and must be
+// freed, by the caller.
+type BSpline struct {
+	*splinter.BSpline
+}
+
+// MarshalMsgpack encodes the spline's degrees, knot vectors and coefficients in MessagePack. Floats are stored as
+// float64, so the encoding is exact.
+func (bs BSpline) MarshalMsgpack() ([]byte, error) {
+	if bs.BSpline == nil {
+		return nil, splinter.ErrInvalidNil
+	}
+
+	var w msgpackWriter
+	err := w.bspline(bs.BSpline)
+	if err != nil {
+		return nil, err
+	}
+	return w.buf, nil
+}
+
+// UnmarshalMsgpack decodes the spline encoded in data by MarshalMsgpack or any MessagePack encoder following the same
+// layout, and makes bs wrap it; a spline wrapped before is left as it is. Integers are accepted where floats are
+// expected and unknown keys are ignored.
+func (bs *BSpline) UnmarshalMsgpack(data []byte) error {
+	r := msgpackReader{data: data}
+	res, err := r.bspline()
+	if err != nil {
+		return err
+	}
+	if r.pos != len(data) {
+		res.Free()
+		return splinter.ErrInvalidEncoding
+	}
+
+	bs.BSpline = res
+	return nil
+}
+
+// UnitAdaptedBSpline is a splinter.UnitAdaptedBSpline with MessagePack encoding methods.
+type UnitAdaptedBSpline struct {
+	*splinter.UnitAdaptedBSpline
+}
+
+// MarshalMsgpack encodes the spline together with the unit conversions in MessagePack, as a map holding the spline
+// under "spline" in the layout of BSpline.MarshalMsgpack, and the conversions under "inputScale", "inputOffset",
+// "outputScale" and "outputOffset".
+func (ub UnitAdaptedBSpline) MarshalMsgpack() ([]byte, error) {
+	if ub.UnitAdaptedBSpline == nil {
+		return nil, splinter.ErrInvalidNil
+	}
+	inputScale, inputOffset, outputScale, outputOffset := ub.Conversions()
+
+	var w msgpackWriter
+	w.mapHeader(5)
+	w.str("spline")
+	err := w.bspline(ub.Spline())
+	if err != nil {
+		return nil, err
+	}
+	w.str("inputScale")
+	w.floats(inputScale)
+	w.str("inputOffset")
+	w.floats(inputOffset)
+	w.str("outputScale")
+	w.float(outputScale)
+	w.str("outputOffset")
+	w.float(outputOffset)
+	return w.buf, nil
+}
+
+// UnmarshalMsgpack decodes the spline and unit conversions encoded in data by MarshalMsgpack, and makes ub wrap them.
+func (ub *UnitAdaptedBSpline) UnmarshalMsgpack(data []byte) error {
+	r := msgpackReader{data: data}
+	n, err := r.mapHeader()
+	if err != nil {
+		return err
+	}
+
+	var bs *splinter.BSpline
+	var inputScale, inputOffset []float64
+	var outputScale, outputOffset float64
+	for i := 0; i < n; i++ {
+		key, err := r.str()
+		if err != nil {
+			return err
+		}
+
+		switch key {
+		case "spline":
+			bs, err = r.bspline()
+		case "inputScale":
+			inputScale, err = r.floats()
+		case "inputOffset":
+			inputOffset, err = r.floats()
+		case "outputScale":
+			outputScale, err = r.number()
+		case "outputOffset":
+			outputOffset, err = r.number()
+		default:
+			err = r.skip(1)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if r.pos != len(data) || bs == nil {
+		return splinter.ErrInvalidEncoding
+	}
+	res, err := splinter.NewUnitAdaptedBSpline(bs, inputScale, inputOffset, outputScale, outputOffset)
+	if err != nil {
+		return err
+	}
+	ub.UnitAdaptedBSpline = res
+	return nil
+}
+
+// bspline appends the MessagePack encoding of bs.
+func (w *msgpackWriter) bspline(bs *splinter.BSpline) error {
+	degrees, err := bs.Degrees()
+	if err != nil {
+		return err
+	}
+	knotVectors, err := bs.KnotVectors()
+	if err != nil {
+		return err
+	}
+	coefficients, err := bs.GetCoefficients()
+	if err != nil {
+		return err
+	}
+
+	nonNegative := bs.IsNonNegative()
+	if nonNegative {
+		w.mapHeader(4)
+	} else {
+		w.mapHeader(3)
+	}
+	w.str("degrees")
+	w.arrayHeader(len(degrees))
+	for _, d := range degrees {
+		w.uint(uint64(d))
+	}
+	w.str("knots")
+	w.arrayHeader(len(knotVectors))
+	for _, knots := range knotVectors {
+		w.floats(knots)
+	}
+	w.str("coefficients")
+	w.floats(coefficients)
+	if nonNegative {
+		w.str("nonNegative")
+		w.bool(true)
+	}
+	return nil
+}
+
+// bspline reads a spline encoded by msgpackWriter.bspline.
+func (r *msgpackReader) bspline() (*splinter.BSpline, error) {
+	n, err := r.mapHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	var degrees []int
+	var knotVectors [][]float64
+	var coefficients []float64
+	nonNegative := false
+	for i := 0; i < n; i++ {
+		key, err := r.str()
+		if err != nil {
+			return nil, err
+		}
+
+		switch key {
+		case "degrees":
+			var values []float64
+			values, err = r.floats()
+			degrees = make([]int, len(values))
+			for j, v := range values {
+				if v != math.Trunc(v) {
+					return nil, splinter.ErrInvalidEncoding
+				}
+				degrees[j] = int(v)
+			}
+		case "knots":
+			var m int
+			m, err = r.arrayHeader()
+			knotVectors = make([][]float64, m)
+			for j := 0; j < m && err == nil; j++ {
+				knotVectors[j], err = r.floats()
+			}
+		case "coefficients":
+			coefficients, err = r.floats()
+		case "nonNegative":
+			nonNegative, err = r.bool()
+		default:
+			err = r.skip(1)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := splinter.NewBSplineFromParts(degrees, knotVectors, coefficients)
+	if err != nil {
+		return nil, err
+	}
+	res.SetNonNegative(nonNegative)
+	return res, nil
+}
@@ -0,0 +1,155 @@
+package msgpackio
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+
+	splinter "github.com/bgrimstad/splinter/include/cinterface"
+)
+
+// newTestSpline returns the bilinear spline 1 + x0 + 2*x1 on the unit square.
+func newTestSpline(t *testing.T) *splinter.BSpline {
+	knots := []float64{0, 0, 1, 1}
+	bs, err := splinter.NewBSplineFromParts([]int{1, 1}, [][]float64{knots, knots}, []float64{1, 3, 2, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bs
+}
+
+func TestMsgpackRoundTrip(t *testing.T) {
+	bs := BSpline{newTestSpline(t)}
+	defer bs.Free()
+	bs.SetNonNegative(true)
+
+	data, err := bs.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded BSpline
+	if err := decoded.UnmarshalMsgpack(data); err != nil {
+		t.Fatal(err)
+	}
+	defer decoded.Free()
+
+	expected, err := bs.GetCoefficients()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := decoded.GetCoefficients()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("coefficients differ after the round trip")
+	}
+	if !decoded.IsNonNegative() {
+		t.Error("expected the clamp to be kept")
+	}
+
+	for _, x := range [][]float64{{0.1, 0.2}, {0.7, 0.9}} {
+		v, err := decoded.Eval(x...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(v-(1+x[0]+2*x[1])) > 1e-12 {
+			t.Errorf("at %v: expected %v, got %v", x, 1+x[0]+2*x[1], v)
+		}
+	}
+
+	if _, err := (BSpline{}).MarshalMsgpack(); err != splinter.ErrInvalidNil {
+		t.Errorf("expected ErrInvalidNil, got %v", err)
+	}
+}
+
+func TestUnmarshalMsgpackForeignEncoding(t *testing.T) {
+	// {"version": "1", "degrees": [1], "knots": [[0, 0, 1, 1]], "coefficients": [2, 4.0]} with integer knots and
+	// coefficients, as other encoders may produce
+	data := []byte{0x84,
+		0xa7, 'v', 'e', 'r', 's', 'i', 'o', 'n', 0xa1, '1',
+		0xa7, 'd', 'e', 'g', 'r', 'e', 'e', 's', 0x91, 0x01,
+		0xa5, 'k', 'n', 'o', 't', 's', 0x91, 0x94, 0x00, 0x00, 0x01, 0xd0, 0x01,
+		0xac, 'c', 'o', 'e', 'f', 'f', 'i', 'c', 'i', 'e', 'n', 't', 's', 0x92, 0x02,
+		0xca, 0x40, 0x80, 0x00, 0x00,
+	}
+
+	var bs BSpline
+	if err := bs.UnmarshalMsgpack(data); err != nil {
+		t.Fatal(err)
+	}
+	defer bs.Free()
+	v, err := bs.Eval(0.25)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(v-2.5) > 1e-12 {
+		t.Errorf("expected 2.5, got %v", v)
+	}
+	if bs.IsNonNegative() {
+		t.Error("expected no clamp without the nonNegative key")
+	}
+
+	if err := bs.UnmarshalMsgpack(data[:len(data)-1]); err != splinter.ErrInvalidEncoding {
+		t.Errorf("expected ErrInvalidEncoding, got %v", err)
+	}
+}
+
+func TestUnmarshalMsgpackNesting(t *testing.T) {
+	// {"extra": [[[...]]]} with the unknown value nested past maxSkipDepth
+	deep := append([]byte{0x81, 0xa5, 'e', 'x', 't', 'r', 'a'}, bytes.Repeat([]byte{0x91}, 100000)...)
+	deep = append(deep, 0xc0)
+
+	var bs BSpline
+	if err := bs.UnmarshalMsgpack(deep); err != splinter.ErrInvalidEncoding {
+		t.Errorf("expected ErrInvalidEncoding, got %v", err)
+	}
+
+	// shallow unknown values are skipped
+	data, err := BSpline{newTestSpline(t)}.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[0]++
+	data = append(data, 0xa5, 'e', 'x', 't', 'r', 'a', 0x91, 0x91, 0x81, 0xc0, 0xc3)
+	if err := bs.UnmarshalMsgpack(data); err != nil {
+		t.Errorf("expected nested unknown values to be skipped, got %v", err)
+	}
+}
+
+func TestUnitAdaptedMsgpackRoundTrip(t *testing.T) {
+	adapted, err := splinter.NewUnitAdaptedBSpline(newTestSpline(t), []float64{2, 0.5}, []float64{0, 0.25}, 10, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ub := UnitAdaptedBSpline{adapted}
+
+	data, err := ub.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded UnitAdaptedBSpline
+	if err := decoded.UnmarshalMsgpack(data); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, x := range [][]float64{{0.1, 0.2}, {0.4, 1}} {
+		want, err := ub.Eval(x...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := decoded.Eval(x...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("at %v: expected %v, got %v", x, want, got)
+		}
+	}
+
+	if err := decoded.UnmarshalMsgpack(data[:len(data)-1]); err != splinter.ErrInvalidEncoding {
+		t.Errorf("expected ErrInvalidEncoding, got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package splinter
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxNDJSONLine bounds the length in bytes of a line NewDataTableFromNDJSON accepts.
+const maxNDJSONLine = 16 << 20
+
+// ErrMalformedLine is returned by NewDataTableFromNDJSON and NewDataTableFromCSV for a line that is not a valid
+// sample, with Line its 1-based number and Err the reason.
+type ErrMalformedLine struct {
+	Line int
+	Err  error
+}
+
+func (e ErrMalformedLine) Error() string {
+	return fmt.Sprintf("Line %d: %v", e.Line, e.Err)
+}
+
+// ndjsonSample is the record NewDataTableFromNDJSON reads from each line.
+type ndjsonSample struct {
+	X []float64 `json:"x"`
+	Y *float64  `json:"y"`
+}
+
+// NewDataTableFromNDJSON creates a table from newline-delimited JSON, one sample per line as a record
+// {"x": [...], "y": ...} holding the inputs and the response. Other fields of the records are ignored, and so are blank
+// lines. Every sample must have the same, non-zero number of inputs as the first one; the first line that is not
+// valid JSON or breaks these rules fails the whole read with an ErrMalformedLine. As with AddColumns, a sample whose
+// inputs repeat an earlier one is discarded.
+func NewDataTableFromNDJSON(r io.Reader) (*DataTable, error) {
+	if r == nil {
+		return nil, ErrInvalidNil
+	}
+
+	var x [][]float64
+	var y []float64
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxNDJSONLine)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var sample ndjsonSample
+		if err := json.Unmarshal([]byte(text), &sample); err != nil {
+			return nil, ErrMalformedLine{Line: line, Err: err}
+		}
+		switch {
+		case sample.X == nil:
+			return nil, ErrMalformedLine{Line: line, Err: errors.New("missing field \"x\"")}
+		case sample.Y == nil:
+			return nil, ErrMalformedLine{Line: line, Err: errors.New("missing field \"y\"")}
+		case len(sample.X) == 0:
+			return nil, ErrMalformedLine{Line: line, Err: errors.New("field \"x\" is empty")}
+		case len(x) > 0 && len(sample.X) != len(x[0]):
+			return nil, ErrMalformedLine{Line: line, Err: ErrDimensionMismatch}
+		}
+
+		x = append(x, sample.X)
+		y = append(y, *sample.Y)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return newDataTableFromSamples(x, y)
+}
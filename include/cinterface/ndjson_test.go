@@ -0,0 +1,50 @@
+package splinter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewDataTableFromNDJSON(t *testing.T) {
+	input := `{"x": [1, 0], "y": 3, "ts": "2020-01-01"}
+
+{"x": [0, 0.5], "y": -1}
+{"y": 2, "x": [0, 0.5]}
+`
+	dt, err := NewDataTableFromNDJSON(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// sorted like splinter, and the repeated inputs discarded
+	x, y := dt.Samples()
+	if !reflect.DeepEqual(x, [][]float64{{0, 0.5}, {1, 0}}) || !reflect.DeepEqual(y, []float64{-1, 3}) {
+		t.Errorf("unexpected samples %v %v", x, y)
+	}
+
+	malformed := []struct {
+		input string
+		line  int
+	}{
+		{`{"x": [1], "y": 1}` + "\n" + `{"x": [1, 2], "y": 1}`, 2},
+		{`{"x": [1], "y": 1}` + "\n\n" + `{"x": [2], "y": }`, 3},
+		{`{"x": [1]}`, 1},
+		{`{"y": 1}`, 1},
+		{`{"x": [], "y": 1}`, 1},
+		{`[1, 2]`, 1},
+	}
+	for _, m := range malformed {
+		_, err := NewDataTableFromNDJSON(strings.NewReader(m.input))
+		if e, ok := err.(ErrMalformedLine); !ok || e.Line != m.line {
+			t.Errorf("%q: expected an error on line %d, got %v", m.input, m.line, err)
+		}
+	}
+
+	empty, err := NewDataTableFromNDJSON(strings.NewReader(""))
+	if err != nil || len(empty.y) != 0 {
+		t.Errorf("expected an empty table, got %v, %v", empty, err)
+	}
+	if _, err := NewDataTableFromNDJSON(nil); err != ErrInvalidNil {
+		t.Errorf("expected ErrInvalidNil, got %v", err)
+	}
+}
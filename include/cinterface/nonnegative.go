@@ -0,0 +1,95 @@
+package splinter
+
+import (
+	"math"
+	"sync"
+)
+
+var (
+	nonNegativeWarningMu sync.RWMutex
+	nonNegativeWarning   func(point []float64, value float64)
+)
+
+// NonNegative asks for a spline that is never negative, as for densities, rates or other quantities that cannot drop
+// below zero. It fails with ErrNegativeSamples if some sample response is negative.
+//
+// splinter cannot constrain the coefficients of a fit, so the fit itself is unchanged and may still undershoot below
+// zero between samples, near steep rises in particular. Instead, the built spline clamps negative values to zero
+// wherever it returns values: Eval in either EvalMode, EvalBatch, EvalBatchSorted, the EvalGrid family and the methods
+// built on them. Derivatives and integrals are not clamped. Each clamped value is reported to the hook set with
+// SetNonNegativeWarning, and MinValue checks whether the clamp is active anywhere; either is a sign that the data
+// would be better served by a smoother fit.
+//
+// The clamp is kept by copies of the spline such as Prune and Subtract, and by the MessagePack and protobuf encodings.
+// splinter's own format, written by Save, MarshalBinary and SaveCompressed, cannot hold it, so a spline loaded from it
+// clamps nothing until SetNonNegative is called.
+func (builder *BSplineBuilder) NonNegative() error {
+	for _, y := range builder.y {
+		if y < 0 {
+			return ErrNegativeSamples
+		}
+	}
+
+	builder.config.NonNegative = true
+	return nil
+}
+
+// MinValue returns the smallest value of the spline over a grid of gridPerDim evenly spaced points per variable
+// spanning its domain, without the clamp of BSplineBuilder.NonNegative. Being a minimum over samples, the true
+// minimum of the spline may be slightly lower; a finer grid tightens it.
+func (bs *BSpline) MinValue(gridPerDim int) (float64, error) {
+	flat, size, err := bs.domainGrid(gridPerDim)
+	if err != nil {
+		return 0, err
+	}
+
+	values, err := bs.evalRowMajorRaw(flat, size)
+	if err != nil {
+		return 0, err
+	}
+
+	min := math.Inf(1)
+	for _, v := range values {
+		min = math.Min(min, v)
+	}
+	return min, nil
+}
+
+// SetNonNegativeWarning sets a hook called with the point and the unclamped value whenever a spline built with
+// BSplineBuilder.NonNegative clamps a negative value to zero, for instance to log the first occurrences. It is called
+// on the evaluating goroutine, possibly from several at once, and must not retain point. A nil warn, the default,
+// removes the hook.
+func SetNonNegativeWarning(warn func(point []float64, value float64)) {
+	nonNegativeWarningMu.Lock()
+	defer nonNegativeWarningMu.Unlock()
+	nonNegativeWarning = warn
+}
+
+// SetNonNegative turns the clamp of negative values to zero described at BSplineBuilder.NonNegative on or off. It is
+// on for splines built with NonNegative and off otherwise. Use it to restore the clamp of a spline loaded from
+// splinter's format, which has no room for it, or to look at the unclamped values of a fit. Like SetEvalMode, it must
+// not be called while the spline is being evaluated.
+func (bs *BSpline) SetNonNegative(on bool) {
+	bs.nonNegative = on
+	if bs.fast != nil {
+		bs.fast.nonNegative = on
+	}
+}
+
+// IsNonNegative reports whether the spline clamps negative values to zero, see SetNonNegative.
+func (bs *BSpline) IsNonNegative() bool {
+	return bs.nonNegative
+}
+
+// clampNegative returns the clamped value of a negative value of a NonNegative spline at point, reporting it to the
+// hook set with SetNonNegativeWarning.
+func clampNegative(point []float64, value float64) float64 {
+	nonNegativeWarningMu.RLock()
+	warn := nonNegativeWarning
+	nonNegativeWarningMu.RUnlock()
+
+	if warn != nil {
+		warn(point, value)
+	}
+	return 0
+}
@@ -0,0 +1,201 @@
+package splinter
+
+import (
+	"math"
+	"testing"
+)
+
+// hinge is zero up to x = 1 and rises steeply after, so cubic fits undershoot below zero before the kink.
+func hinge(x []float64) float64 {
+	return 10 * math.Max(0, x[0]-1)
+}
+
+func TestNonNegative(t *testing.T) {
+	xs := linspace(0, 2, 21)
+	ys := make([]float64, len(xs))
+	for i, x := range xs {
+		ys[i] = hinge([]float64{x})
+	}
+	dt, err := NewDataTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dt.AddColumns(xs, ys); err != nil {
+		t.Fatal(err)
+	}
+	raw := buildTestSpline(t, [][]float64{xs}, hinge)
+
+	builder, err := NewBSplineBuilder(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.NonNegative(); err != nil {
+		t.Fatal(err)
+	}
+	bs, err := builder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	min, err := bs.MinValue(201)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if min >= 0 {
+		t.Fatalf("expected the fit to undershoot, got minimum %v", min)
+	}
+	rawMin, err := raw.MinValue(201)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual(min, rawMin, 1e-12) {
+		t.Errorf("expected MinValue to ignore the clamp, got %v and %v", min, rawMin)
+	}
+
+	var warnings int
+	SetNonNegativeWarning(func(point []float64, value float64) {
+		if len(point) != 1 || value >= 0 {
+			t.Errorf("unexpected warning for %v at %v", value, point)
+		}
+		warnings++
+	})
+	defer SetNonNegativeWarning(nil)
+
+	xs = linspace(0, 2, 201)
+	expected := make([]float64, len(xs))
+	clamped := 0
+	for i, x := range xs {
+		v, err := bs.Eval(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		u, err := raw.Eval(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u < 0 {
+			clamped++
+		}
+		expected[i] = math.Max(0, u)
+		if v < 0 || !almostEqual(v, expected[i], 1e-12) {
+			t.Fatalf("at %v: expected %v, got %v", x, expected[i], v)
+		}
+	}
+	if warnings != clamped {
+		t.Errorf("expected %d warnings from Eval, got %d", clamped, warnings)
+	}
+
+	// every way of evaluating the spline gives the same, clamped values
+	points := make([][]float64, len(xs))
+	for i, x := range xs {
+		points[i] = []float64{x}
+	}
+	evaluations := map[string]func() ([]float64, error){
+		"EvalBatch":       func() ([]float64, error) { return bs.EvalBatch(points) },
+		"EvalPath":        func() ([]float64, error) { return bs.EvalPath(points) },
+		"EvalBatchSorted": func() ([]float64, error) { return bs.EvalBatchSorted(points) },
+		"EvalGrid":        func() ([]float64, error) { return bs.EvalGrid([][]float64{xs}) },
+		"EvalFast": func() ([]float64, error) {
+			if err := bs.SetEvalMode(EvalFast); err != nil {
+				return nil, err
+			}
+			defer bs.SetEvalMode(EvalPrecise)
+
+			values := make([]float64, len(xs))
+			for i, x := range xs {
+				v, err := bs.Eval(x)
+				if err != nil {
+					return nil, err
+				}
+				values[i] = v
+			}
+			return values, nil
+		},
+	}
+	for name, eval := range evaluations {
+		values, err := eval()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		for i, v := range values {
+			if v < 0 || !almostEqual(v, expected[i], 1e-9) {
+				t.Errorf("%s at %v: expected %v, got %v", name, xs[i], expected[i], v)
+				break
+			}
+		}
+	}
+
+	if _, err := bs.MinValue(1); err != ErrInvalidCount {
+		t.Errorf("expected ErrInvalidCount, got %v", err)
+	}
+}
+
+func TestNonNegativeCopies(t *testing.T) {
+	bs := buildTestSpline(t, [][]float64{linspace(0, 2, 21)}, hinge)
+	if bs.IsNonNegative() {
+		t.Fatal("expected a spline built without NonNegative not to clamp")
+	}
+	bs.SetNonNegative(true)
+
+	pruned, _, err := bs.Prune(1e-12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	binary, err := bs.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var unmarshaled BSpline
+	if err := unmarshaled.UnmarshalBinary(binary); err != nil {
+		t.Fatal(err)
+	}
+
+	if !pruned.IsNonNegative() {
+		t.Error("expected Prune to keep the clamp")
+	}
+	if unmarshaled.IsNonNegative() {
+		t.Error("expected splinter's format to drop the clamp")
+	}
+
+	// the copies clamp like the original, and stop when it is turned off
+	min, err := bs.MinValue(201)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x := 0.0
+	for _, u := range linspace(0, 2, 201) {
+		if v, err := bs.evalPoint([]float64{u}); err == nil && v == min {
+			x = u
+		}
+	}
+	if v, err := pruned.Eval(x); err != nil || v != 0 {
+		t.Errorf("expected the pruned copy to clamp %v at %v, got %v, %v", min, x, v, err)
+	}
+	if err := bs.SetEvalMode(EvalFast); err != nil {
+		t.Fatal(err)
+	}
+	bs.SetNonNegative(false)
+	if v, err := bs.Eval(x); err != nil || v >= 0 {
+		t.Errorf("expected the unclamped value at %v, got %v, %v", x, v, err)
+	}
+}
+
+func TestNonNegativeRejectsNegativeSamples(t *testing.T) {
+	dt, err := newTestSpline1D(t).Resample([][]float64{linspace(0, 2, 11)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dt.AddColumns([]float64{2.5}, []float64{-1}); err != nil {
+		t.Fatal(err)
+	}
+	builder, err := NewBSplineBuilder(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.NonNegative(); err != ErrNegativeSamples {
+		t.Errorf("expected ErrNegativeSamples, got %v", err)
+	}
+	if builder.config.NonNegative {
+		t.Error("a rejected request should not be recorded")
+	}
+}
@@ -0,0 +1,76 @@
+package splinter
+
+// BuilderOption is a setting applied to a BSplineBuilder by BuildBSpline, see the With functions.
+type BuilderOption func(builder *BSplineBuilder) error
+
+// BuildBSpline builds a spline from the samples in table with the given options applied in order, stopping at the
+// first error. The builder is freed before returning. Settings not given keep splinter's defaults, as with Fit.
+func BuildBSpline(table *DataTable, opts ...BuilderOption) (*BSpline, error) {
+	builder, err := NewBSplineBuilder(table)
+	if err != nil {
+		return nil, err
+	}
+	defer builder.Free()
+
+	for _, opt := range opts {
+		if err := opt(builder); err != nil {
+			return nil, err
+		}
+	}
+	return builder.Build()
+}
+
+// WithKnotSpacing sets the knot spacing, see BSplineBuilder.KnotSpacing.
+func WithKnotSpacing(ks KnotSpacing) BuilderOption {
+	return func(builder *BSplineBuilder) error { return builder.KnotSpacing(ks) }
+}
+
+// WithSmoothing sets the smoothing, see BSplineBuilder.Smoothing.
+func WithSmoothing(s Smoothing) BuilderOption {
+	return func(builder *BSplineBuilder) error { return builder.Smoothing(s) }
+}
+
+// WithAlpha sets the smoothing parameter, see BSplineBuilder.Alpha.
+func WithAlpha(alpha float64) BuilderOption {
+	return func(builder *BSplineBuilder) error { return builder.Alpha(alpha) }
+}
+
+// WithPadding sets the padding of the knot vectors, see BSplineBuilder.Padding.
+func WithPadding(padding float64) BuilderOption {
+	return func(builder *BSplineBuilder) error { return builder.Padding(padding) }
+}
+
+// WithWeights sets the weights of the samples, see BSplineBuilder.Weights.
+func WithWeights(weights []float64) BuilderOption {
+	return func(builder *BSplineBuilder) error { return builder.Weights(weights) }
+}
+
+// WithBounds sets the bounds of the domain, see BSplineBuilder.Bounds.
+func WithBounds(bounds [][]float64) BuilderOption {
+	return func(builder *BSplineBuilder) error { return builder.Bounds(bounds) }
+}
+
+// WithHfsIters sets the number of HFS iterations optimizing alpha, see BSplineBuilder.HfsIters.
+func WithHfsIters(iters uint) BuilderOption {
+	return func(builder *BSplineBuilder) error { return builder.HfsIters(iters) }
+}
+
+// WithNumBasisFunctions sets the number of basis functions of each variable, see BSplineBuilder.NumBasisFunctions.
+func WithNumBasisFunctions(n []int) BuilderOption {
+	return func(builder *BSplineBuilder) error { return builder.NumBasisFunctions(n) }
+}
+
+// WithDegree sets the degree of each variable, see BSplineBuilder.Degree.
+func WithDegree(degrees []int) BuilderOption {
+	return func(builder *BSplineBuilder) error { return builder.Degree(degrees) }
+}
+
+// WithNonNegative asks for a spline that is never negative, see BSplineBuilder.NonNegative.
+func WithNonNegative() BuilderOption {
+	return func(builder *BSplineBuilder) error { return builder.NonNegative() }
+}
+
+// WithConfig applies all the settings in cfg, see BSplineBuilder.Configure.
+func WithConfig(cfg BuilderConfig) BuilderOption {
+	return func(builder *BSplineBuilder) error { return builder.Configure(cfg) }
+}
@@ -0,0 +1,45 @@
+package splinter
+
+import (
+	"testing"
+)
+
+func TestBuildBSpline(t *testing.T) {
+	dt, err := newTestSpline1D(t).Resample([][]float64{linspace(0, 2, 21)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bs, err := BuildBSpline(dt,
+		WithKnotSpacing(KnotSpacingEquidistant),
+		WithNumBasisFunctions([]int{8}),
+		WithSmoothing(SmoothingPspline),
+		WithAlpha(0.01),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := bs.NumCoefficients(); err != nil || n >= 21 {
+		t.Errorf("expected fewer coefficients than samples, got %v, %v", n, err)
+	}
+	if v, err := bs.Eval(1); err != nil || !almostEqual(v, 1, 0.05) {
+		t.Errorf("expected f(1) close to 1, got %v, %v", v, err)
+	}
+	if bs.config.Smoothing != SmoothingPspline || bs.config.Alpha != 0.01 {
+		t.Errorf("expected the options to be recorded, got %+v", bs.config)
+	}
+
+	// the first failing option stops the build
+	applied := false
+	_, err = BuildBSpline(dt, WithDegree([]int{7}), func(*BSplineBuilder) error {
+		applied = true
+		return nil
+	})
+	if err != ErrInvalidDegree || applied {
+		t.Errorf("expected ErrInvalidDegree before the next option, got %v (applied: %v)", err, applied)
+	}
+
+	if _, err := BuildBSpline(nil); err != ErrInvalidNil {
+		t.Errorf("expected ErrInvalidNil, got %v", err)
+	}
+}
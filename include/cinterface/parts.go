@@ -0,0 +1,202 @@
+package splinter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+)
+
+// checkParts verifies that degrees, knot vectors and coefficients describe a spline splinter can load: one degree of
+// at least 1 and one clamped, non-decreasing knot vector per variable, with no knot repeated more than degree+1
+// times, and one coefficient per tensor product basis function.
+func checkParts(degrees []int, knotVectors [][]float64, coefficients []float64) error {
+	if len(degrees) == 0 || len(degrees) != len(knotVectors) {
+		return ErrInvalidSpline
+	}
+
+	numBasisFunctions := 1
+	for i, knots := range knotVectors {
+		p := degrees[i]
+		if p < 1 || len(knots) < 2*(p+1) {
+			return ErrInvalidSpline
+		}
+
+		multiplicity := 1
+		for j := 1; j < len(knots); j++ {
+			if math.IsNaN(knots[j]) || knots[j] < knots[j-1] {
+				return ErrInvalidSpline
+			}
+			if knots[j] == knots[j-1] {
+				multiplicity++
+			} else {
+				multiplicity = 1
+			}
+			if multiplicity > p+1 {
+				return ErrInvalidSpline
+			}
+		}
+		if knots[p] != knots[0] || knots[len(knots)-1-p] != knots[len(knots)-1] {
+			return ErrInvalidSpline
+		}
+
+		numBasisFunctions *= len(knots) - p - 1
+	}
+
+	if len(coefficients) != numBasisFunctions {
+		return ErrInvalidSpline
+	}
+	return nil
+}
+
+// knotAverages returns the control point inputs of a spline, one row of numVariables knot averages per coefficient,
+// computed like splinter's BSpline::computeKnotAverages.
+func knotAverages(degrees []int, knotVectors [][]float64) [][]float64 {
+	mu := make([][]float64, len(knotVectors))
+	for i, knots := range knotVectors {
+		p := degrees[i]
+		mu[i] = make([]float64, len(knots)-p-1)
+		for j := range mu[i] {
+			avg := 0.0
+			for k := j + 1; k <= j+p; k++ {
+				avg += knots[k]
+			}
+			mu[i][j] = avg / float64(p)
+		}
+	}
+
+	averages := make([][]float64, gridSize(mu))
+	for k := range averages {
+		averages[k] = make([]float64, len(mu))
+		gridPoint(mu, k, averages[k])
+	}
+	return averages
+}
+
+// encodeParts writes a spline in splinter's binary format, which is the in-memory layout of its members: sizes are
+// 64-bit and unsigned ints 32-bit, little-endian, as on the platforms splinter is built for.
+func encodeParts(degrees []int, knotVectors [][]float64, coefficients []float64) []byte {
+	var buf bytes.Buffer
+	write := func(v interface{}) {
+		// writes to a bytes.Buffer cannot fail
+		binary.Write(&buf, binary.LittleEndian, v)
+	}
+
+	// BSplineBasis: the univariate bases, then the number of variables
+	write(uint64(len(knotVectors)))
+	for i, knots := range knotVectors {
+		p := uint32(degrees[i])
+		write(p)
+		write(uint64(len(knots)))
+		write(knots)
+		// the target splinter's BSplineBasis1D constructor sets, only used for knot refinement
+		write(3*p + 2)
+	}
+	write(uint32(len(knotVectors)))
+
+	// knot averages as a row major matrix, coefficients as a vector
+	averages := knotAverages(degrees, knotVectors)
+	write(int64(len(averages)))
+	write(int64(len(knotVectors)))
+	for _, row := range averages {
+		write(row)
+	}
+	write(int64(len(coefficients)))
+	write(coefficients)
+
+	write(uint32(len(knotVectors)))
+	return buf.Bytes()
+}
+
+// NewBSplineFromParts creates a spline from its degrees, knot vectors and coefficients, as returned by Degrees,
+// KnotVectors and GetCoefficients, for instance to decode a spline from an encoding of its own. The parts must describe
+// a spline splinter can load: one degree of at least 1 and one clamped, non-decreasing knot vector per variable, and
+// one coefficient per basis function, ordered with the last variable varying fastest. ErrInvalidSpline is returned
+// otherwise.
+func NewBSplineFromParts(degrees []int, knotVectors [][]float64, coefficients []float64) (*BSpline, error) {
+	err := checkParts(degrees, knotVectors, coefficients)
+	if err != nil {
+		return nil, err
+	}
+	return loadBSplineBytes(encodeParts(degrees, knotVectors, coefficients))
+}
+
+// decodeParts reads a spline written in splinter's binary format (see encodeParts). If data does not follow that layout
+// exactly, it returns ErrCorruptData when the layout was recognised, that is the header and the first basis are
+// plausible, and ErrInvalidEncoding otherwise. Sizes are checked against the remaining data before anything is
+// allocated, so malformed input cannot trigger huge allocations.
+func decodeParts(data []byte) (degrees []int, knotVectors [][]float64, coefficients []float64, err error) {
+	r := bytes.NewReader(data)
+	read := func(v interface{}) {
+		if err == nil {
+			err = binary.Read(r, binary.LittleEndian, v)
+		}
+	}
+	// floats reads a length-prefixed vector of n doubles, n being read as a 64-bit size
+	floats := func(n uint64) []float64 {
+		if err != nil {
+			return nil
+		}
+		if n > uint64(r.Len())/8 {
+			err = ErrInvalidEncoding
+			return nil
+		}
+		v := make([]float64, n)
+		read(v)
+		return v
+	}
+
+	// whether the data starts like a spline, so later errors mean it is damaged rather than in another format
+	recognised := false
+
+	var numBases uint64
+	read(&numBases)
+	// each univariate basis takes at least 16 bytes
+	if err == nil && (numBases == 0 || numBases > uint64(r.Len())/16) {
+		return nil, nil, nil, ErrInvalidEncoding
+	}
+	numBasisFunctions := uint64(1)
+	for i := uint64(0); i < numBases && err == nil; i++ {
+		var degree, target uint32
+		var numKnots uint64
+		read(&degree)
+		read(&numKnots)
+		knots := floats(numKnots)
+		read(&target)
+		if err == nil && (degree < 1 || numKnots < 2*(uint64(degree)+1)) {
+			err = ErrInvalidEncoding
+		}
+		degrees = append(degrees, int(degree))
+		knotVectors = append(knotVectors, knots)
+		recognised = recognised || err == nil
+		numBasisFunctions *= numKnots - uint64(degree) - 1
+	}
+
+	var basisVariables, numVariables uint32
+	var rows, cols, length int64
+	read(&basisVariables)
+	read(&rows)
+	read(&cols)
+	if err == nil && (rows < 0 || uint64(rows) > uint64(r.Len())/8 || uint64(rows) != numBasisFunctions ||
+		cols < 0 || uint64(cols) != numBases) {
+		err = ErrInvalidEncoding
+	}
+	// the knot averages are recomputed from the knots when needed
+	floats(uint64(rows) * uint64(cols))
+	read(&length)
+	if err == nil && length != rows {
+		err = ErrInvalidEncoding
+	}
+	coefficients = floats(uint64(length))
+	read(&numVariables)
+
+	if err == nil && (uint64(basisVariables) != numBases || uint64(numVariables) != numBases || r.Len() != 0) {
+		err = ErrInvalidEncoding
+	}
+	if err != nil {
+		if recognised {
+			return nil, nil, nil, ErrCorruptData
+		}
+		return nil, nil, nil, ErrInvalidEncoding
+	}
+	return degrees, knotVectors, coefficients, nil
+}
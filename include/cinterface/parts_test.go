@@ -0,0 +1,69 @@
+package splinter
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEncodePartsMatchesSplinter(t *testing.T) {
+	for _, bs := range []*BSpline{newTestSpline1D(t), newTestSpline2D(t)} {
+		degrees, err := bs.Degrees()
+		if err != nil {
+			t.Fatal(err)
+		}
+		knotVectors, err := bs.KnotVectors()
+		if err != nil {
+			t.Fatal(err)
+		}
+		coefficients, err := bs.GetCoefficients()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		saved, err := bs.saveBytes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(encodeParts(degrees, knotVectors, coefficients), saved) {
+			t.Errorf("encoding differs from splinter's for %d variables", len(degrees))
+		}
+	}
+}
+
+func TestNewBSplineFromParts(t *testing.T) {
+	// a linear spline on [0, 2] with a kink at 1
+	bs, err := NewBSplineFromParts([]int{1}, [][]float64{{0, 0, 1, 2, 2}}, []float64{0, 1, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, x := range []float64{0.5, 1, 1.5} {
+		got, err := bs.Eval(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := 1 - (x - 1)
+		if x < 1 {
+			expected = x
+		}
+		if !almostEqual(got, expected, 1e-12) {
+			t.Errorf("at %v: expected %v, got %v", x, expected, got)
+		}
+	}
+
+	invalid := []struct {
+		degrees      []int
+		knotVectors  [][]float64
+		coefficients []float64
+	}{
+		{[]int{1}, [][]float64{{0, 0, 1, 2, 2}}, []float64{0, 1}},
+		{[]int{1}, [][]float64{{0, 0, 2, 1, 2}}, []float64{0, 1, 0}},
+		{[]int{1}, [][]float64{{0, 1, 1, 2, 2}}, []float64{0, 1, 0}},
+		{[]int{0}, [][]float64{{0, 1}}, []float64{0}},
+		{[]int{1, 1}, [][]float64{{0, 0, 1, 1}}, []float64{0, 1}},
+	}
+	for _, c := range invalid {
+		if _, err := NewBSplineFromParts(c.degrees, c.knotVectors, c.coefficients); err != ErrInvalidSpline {
+			t.Errorf("%v: expected ErrInvalidSpline, got %v", c, err)
+		}
+	}
+}
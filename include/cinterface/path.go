@@ -0,0 +1,9 @@
+package splinter
+
+// EvalPath evaluates the spline at each point of a path through its input space, such as a trajectory, returning one
+// value per point. It is EvalBatch under a name that reads better for paths: each point must hold one value per
+// variable, a point that does not gives an ErrInvalidPoint, and the whole path is evaluated in a single call into
+// splinter.
+func (bs *BSpline) EvalPath(path [][]float64) ([]float64, error) {
+	return bs.EvalBatch(path)
+}
@@ -0,0 +1,33 @@
+package splinter
+
+import (
+	"testing"
+)
+
+func TestEvalPath(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	// a diagonal trajectory across the unit square
+	path := make([][]float64, 0, 11)
+	for _, s := range linspace(0, 1, 11) {
+		path = append(path, []float64{s, 1 - s})
+	}
+
+	values, err := bs.EvalPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != len(path) {
+		t.Fatalf("expected %d values, got %d", len(path), len(values))
+	}
+	for i, v := range values {
+		if !almostEqual(v, bilinearish(path[i]), 1e-9) {
+			t.Errorf("at %v: expected %v, got %v", path[i], bilinearish(path[i]), v)
+		}
+	}
+
+	_, err = bs.EvalPath([][]float64{{0.5, 0.5}, {0.5}})
+	if e, ok := err.(ErrInvalidPoint); !ok || e.Index != 1 || e.Err != ErrDimensionMismatch {
+		t.Errorf("expected point 1 to mismatch, got %v", err)
+	}
+}
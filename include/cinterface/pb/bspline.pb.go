@@ -0,0 +1,330 @@
+// Protocol buffer representation of a SPLINTER B-spline, for exchanging splines between services without the
+// binary SPLINTER format. The Go types in this directory are generated from it with protoc-gen-go.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        (unknown)
+// source: bspline.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// KnotVector holds the knots of one variable, in non-decreasing order.
+type KnotVector struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Knots []float64 `protobuf:"fixed64,1,rep,packed,name=knots,proto3" json:"knots,omitempty"`
+}
+
+func (x *KnotVector) Reset() {
+	*x = KnotVector{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspline_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KnotVector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KnotVector) ProtoMessage() {}
+
+func (x *KnotVector) ProtoReflect() protoreflect.Message {
+	mi := &file_bspline_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KnotVector.ProtoReflect.Descriptor instead.
+func (*KnotVector) Descriptor() ([]byte, []int) {
+	return file_bspline_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *KnotVector) GetKnots() []float64 {
+	if x != nil {
+		return x.Knots
+	}
+	return nil
+}
+
+// Interval is a closed range [min, max].
+type Interval struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Min float64 `protobuf:"fixed64,1,opt,name=min,proto3" json:"min,omitempty"`
+	Max float64 `protobuf:"fixed64,2,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *Interval) Reset() {
+	*x = Interval{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspline_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Interval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Interval) ProtoMessage() {}
+
+func (x *Interval) ProtoReflect() protoreflect.Message {
+	mi := &file_bspline_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Interval.ProtoReflect.Descriptor instead.
+func (*Interval) Descriptor() ([]byte, []int) {
+	return file_bspline_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Interval) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *Interval) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+// BSpline is a tensor product B-spline: one degree and knot vector per variable, and one coefficient per basis
+// function, ordered with the last variable varying fastest. The domain is implied by the knots and only included for
+// the convenience of readers. non_negative is set for splines whose negative values are clamped to zero.
+type BSpline struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Degrees      []uint32      `protobuf:"varint,1,rep,packed,name=degrees,proto3" json:"degrees,omitempty"`
+	KnotVectors  []*KnotVector `protobuf:"bytes,2,rep,name=knot_vectors,json=knotVectors,proto3" json:"knot_vectors,omitempty"`
+	Coefficients []float64     `protobuf:"fixed64,3,rep,packed,name=coefficients,proto3" json:"coefficients,omitempty"`
+	Domain       []*Interval   `protobuf:"bytes,4,rep,name=domain,proto3" json:"domain,omitempty"`
+	NonNegative  bool          `protobuf:"varint,5,opt,name=non_negative,json=nonNegative,proto3" json:"non_negative,omitempty"`
+}
+
+func (x *BSpline) Reset() {
+	*x = BSpline{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bspline_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BSpline) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BSpline) ProtoMessage() {}
+
+func (x *BSpline) ProtoReflect() protoreflect.Message {
+	mi := &file_bspline_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BSpline.ProtoReflect.Descriptor instead.
+func (*BSpline) Descriptor() ([]byte, []int) {
+	return file_bspline_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BSpline) GetDegrees() []uint32 {
+	if x != nil {
+		return x.Degrees
+	}
+	return nil
+}
+
+func (x *BSpline) GetKnotVectors() []*KnotVector {
+	if x != nil {
+		return x.KnotVectors
+	}
+	return nil
+}
+
+func (x *BSpline) GetCoefficients() []float64 {
+	if x != nil {
+		return x.Coefficients
+	}
+	return nil
+}
+
+func (x *BSpline) GetDomain() []*Interval {
+	if x != nil {
+		return x.Domain
+	}
+	return nil
+}
+
+func (x *BSpline) GetNonNegative() bool {
+	if x != nil {
+		return x.NonNegative
+	}
+	return false
+}
+
+var File_bspline_proto protoreflect.FileDescriptor
+
+var file_bspline_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x62, 0x73, 0x70, 0x6c, 0x69, 0x6e, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x08, 0x73, 0x70, 0x6c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x22, 0x22, 0x0a, 0x0a, 0x4b, 0x6e, 0x6f,
+	0x74, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6b, 0x6e, 0x6f, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x01, 0x52, 0x05, 0x6b, 0x6e, 0x6f, 0x74, 0x73, 0x22, 0x2e, 0x0a,
+	0x08, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d,
+	0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x22, 0xcf, 0x01,
+	0x0a, 0x07, 0x42, 0x53, 0x70, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x67,
+	0x72, 0x65, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x07, 0x64, 0x65, 0x67, 0x72,
+	0x65, 0x65, 0x73, 0x12, 0x37, 0x0a, 0x0c, 0x6b, 0x6e, 0x6f, 0x74, 0x5f, 0x76, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x70, 0x6c, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x2e, 0x4b, 0x6e, 0x6f, 0x74, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52,
+	0x0b, 0x6b, 0x6e, 0x6f, 0x74, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x22, 0x0a, 0x0c,
+	0x63, 0x6f, 0x65, 0x66, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x01, 0x52, 0x0c, 0x63, 0x6f, 0x65, 0x66, 0x66, 0x69, 0x63, 0x69, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x2a, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x73, 0x70, 0x6c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x2e, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x76, 0x61, 0x6c, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x21, 0x0a, 0x0c,
+	0x6e, 0x6f, 0x6e, 0x5f, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0b, 0x6e, 0x6f, 0x6e, 0x4e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x42,
+	0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x67,
+	0x72, 0x69, 0x6d, 0x73, 0x74, 0x61, 0x64, 0x2f, 0x73, 0x70, 0x6c, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x2f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x2f, 0x63, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_bspline_proto_rawDescOnce sync.Once
+	file_bspline_proto_rawDescData = file_bspline_proto_rawDesc
+)
+
+func file_bspline_proto_rawDescGZIP() []byte {
+	file_bspline_proto_rawDescOnce.Do(func() {
+		file_bspline_proto_rawDescData = protoimpl.X.CompressGZIP(file_bspline_proto_rawDescData)
+	})
+	return file_bspline_proto_rawDescData
+}
+
+var file_bspline_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_bspline_proto_goTypes = []interface{}{
+	(*KnotVector)(nil), // 0: splinter.KnotVector
+	(*Interval)(nil),   // 1: splinter.Interval
+	(*BSpline)(nil),    // 2: splinter.BSpline
+}
+var file_bspline_proto_depIdxs = []int32{
+	0, // 0: splinter.BSpline.knot_vectors:type_name -> splinter.KnotVector
+	1, // 1: splinter.BSpline.domain:type_name -> splinter.Interval
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_bspline_proto_init() }
+func file_bspline_proto_init() {
+	if File_bspline_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_bspline_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KnotVector); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspline_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Interval); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bspline_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BSpline); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_bspline_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_bspline_proto_goTypes,
+		DependencyIndexes: file_bspline_proto_depIdxs,
+		MessageInfos:      file_bspline_proto_msgTypes,
+	}.Build()
+	File_bspline_proto = out.File
+	file_bspline_proto_rawDesc = nil
+	file_bspline_proto_goTypes = nil
+	file_bspline_proto_depIdxs = nil
+}
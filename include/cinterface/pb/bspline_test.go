@@ -0,0 +1,73 @@
+package pb
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestMarshalBSpline(t *testing.T) {
+	m := &BSpline{
+		Degrees:      []uint32{3},
+		KnotVectors:  []*KnotVector{{Knots: []float64{0, 1}}},
+		Coefficients: []float64{2},
+		Domain:       []*Interval{{Min: 0, Max: 1}},
+	}
+
+	data, err := proto.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte{
+		// degrees, packed
+		0x0a, 0x01, 0x03,
+		// one knot vector holding packed knots 0 and 1
+		0x12, 0x12, 0x0a, 0x10, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f,
+		// coefficients, packed
+		0x1a, 0x08, 0, 0, 0, 0, 0, 0, 0, 0x40,
+		// one interval, min 0 omitted
+		0x22, 0x09, 0x11, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f,
+	}
+	if !bytes.Equal(data, expected) {
+		t.Errorf("expected % x, got % x", expected, data)
+	}
+
+	var decoded BSpline
+	if err := proto.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(&decoded, m) {
+		t.Errorf("expected %v, got %v", m, &decoded)
+	}
+}
+
+func TestUnmarshalBSplineUnpackedAndUnknown(t *testing.T) {
+	data := []byte{
+		// unpacked degrees 2 and 3
+		0x08, 0x02, 0x08, 0x03,
+		// an unknown varint field 9
+		0x48, 0x96, 0x01,
+		// unpacked coefficient 2
+		0x19, 0, 0, 0, 0, 0, 0, 0, 0x40,
+	}
+	var m BSpline
+	if err := proto.Unmarshal(data, &m); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(m.Degrees, []uint32{2, 3}) || !reflect.DeepEqual(m.Coefficients, []float64{2}) {
+		t.Errorf("unexpected message %v", &m)
+	}
+
+	for _, bad := range [][]byte{
+		{0x0a, 0x05, 0x03},
+		{0x1a, 0x03, 0, 0, 0},
+		{0x12, 0x01, 0x09},
+		{0x00},
+	} {
+		if err := proto.Unmarshal(bad, &m); err == nil {
+			t.Errorf("% x: expected an error", bad)
+		}
+	}
+}
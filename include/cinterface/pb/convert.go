@@ -0,0 +1,72 @@
+package pb
+
+import (
+	splinter "github.com/bgrimstad/splinter/include/cinterface"
+)
+
+// ToProto returns bs as a protocol buffer message (see bspline.proto), holding its degrees, knot vectors, coefficients
+// and domain, and whether it clamps negative values.
+func ToProto(bs *splinter.BSpline) (*BSpline, error) {
+	if bs == nil {
+		return nil, splinter.ErrInvalidNil
+	}
+
+	degrees, err := bs.Degrees()
+	if err != nil {
+		return nil, err
+	}
+	knotVectors, err := bs.KnotVectors()
+	if err != nil {
+		return nil, err
+	}
+	coefficients, err := bs.GetCoefficients()
+	if err != nil {
+		return nil, err
+	}
+	domain, err := bs.GetDomain()
+	if err != nil {
+		return nil, err
+	}
+
+	m := &BSpline{Coefficients: coefficients, NonNegative: bs.IsNonNegative()}
+	for i, knots := range knotVectors {
+		m.Degrees = append(m.Degrees, uint32(degrees[i]))
+		m.KnotVectors = append(m.KnotVectors, &KnotVector{Knots: knots})
+		m.Domain = append(m.Domain, &Interval{Min: domain[i][0], Max: domain[i][1]})
+	}
+	return m, nil
+}
+
+// FromProto creates a spline from a message produced by ToProto or another implementation of bspline.proto. The
+// message must describe a valid spline and, if it has a domain, the domain must match the ends of the knot vectors;
+// splinter.ErrInvalidSpline is returned otherwise.
+func FromProto(m *BSpline) (*splinter.BSpline, error) {
+	if m == nil {
+		return nil, splinter.ErrInvalidNil
+	}
+	if len(m.Degrees) != len(m.KnotVectors) || (len(m.Domain) > 0 && len(m.Domain) != len(m.KnotVectors)) {
+		return nil, splinter.ErrInvalidSpline
+	}
+
+	degrees := make([]int, len(m.Degrees))
+	knotVectors := make([][]float64, len(m.KnotVectors))
+	for i, kv := range m.KnotVectors {
+		degrees[i] = int(m.Degrees[i])
+		if kv == nil || len(kv.Knots) == 0 {
+			return nil, splinter.ErrInvalidSpline
+		}
+		knotVectors[i] = kv.Knots
+		if len(m.Domain) > 0 {
+			d := m.Domain[i]
+			if d == nil || d.Min != kv.Knots[0] || d.Max != kv.Knots[len(kv.Knots)-1] {
+				return nil, splinter.ErrInvalidSpline
+			}
+		}
+	}
+	res, err := splinter.NewBSplineFromParts(degrees, knotVectors, m.Coefficients)
+	if err != nil {
+		return nil, err
+	}
+	res.SetNonNegative(m.NonNegative)
+	return res, nil
+}
@@ -0,0 +1,71 @@
+package pb
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	splinter "github.com/bgrimstad/splinter/include/cinterface"
+)
+
+func TestProtoRoundTrip(t *testing.T) {
+	// the bilinear spline 1 + x0 + 2*x1 on the unit square
+	knots := []float64{0, 0, 1, 1}
+	bs, err := splinter.NewBSplineFromParts([]int{1, 1}, [][]float64{knots, knots}, []float64{1, 3, 2, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bs.Free()
+	bs.SetNonNegative(true)
+
+	m, err := ToProto(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := proto.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded BSpline
+	if err := proto.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	restored, err := FromProto(&decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer restored.Free()
+
+	for _, x := range [][]float64{{0, 0}, {0.3, 0.7}, {1, 0.25}} {
+		want, err := bs.Eval(x...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := restored.Eval(x...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("at %v: expected %v, got %v", x, want, got)
+		}
+	}
+	if !restored.IsNonNegative() {
+		t.Error("expected the clamp to be kept")
+	}
+
+	decoded.Domain[0].Max = 2
+	if _, err := FromProto(&decoded); err != splinter.ErrInvalidSpline {
+		t.Errorf("expected ErrInvalidSpline for a domain not matching the knots, got %v", err)
+	}
+	decoded.Domain = nil
+	decoded.Degrees = decoded.Degrees[:1]
+	if _, err := FromProto(&decoded); err != splinter.ErrInvalidSpline {
+		t.Errorf("expected ErrInvalidSpline for missing degrees, got %v", err)
+	}
+	if _, err := FromProto(nil); err != splinter.ErrInvalidNil {
+		t.Errorf("expected ErrInvalidNil, got %v", err)
+	}
+	if _, err := ToProto(nil); err != splinter.ErrInvalidNil {
+		t.Errorf("expected ErrInvalidNil, got %v", err)
+	}
+}
@@ -0,0 +1,8 @@
+// Package pb holds the protocol buffer messages of bspline.proto, generated with protoc-gen-go, so that splines can be
+// exchanged with services speaking protobuf. ToProto and FromProto convert between splines and messages; encode and
+// decode the messages with google.golang.org/protobuf/proto.
+//
+// The package is a module of its own, so that neither the splinter package nor its module depend on protobuf.
+package pb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative bspline.proto
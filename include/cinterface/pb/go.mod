@@ -0,0 +1,11 @@
+module github.com/bgrimstad/splinter/include/cinterface/pb
+
+go 1.17
+
+require (
+	github.com/bgrimstad/splinter v0.0.0-20261016173520-386fb0fdcb53
+	google.golang.org/protobuf v1.33.0
+)
+
+// Within this repository, build against the splinter module next to this one rather than the required version.
+replace github.com/bgrimstad/splinter => ../../..
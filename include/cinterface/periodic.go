@@ -0,0 +1,93 @@
+package splinter
+
+import (
+	"math"
+	"sort"
+)
+
+// periodicMargin is the fraction of the period, at each end of the samples, that Periodic copies across the seam.
+const periodicMargin = 0.25
+
+// Periodic makes the fit treat variable dim as periodic with the given period, such as an angle with period 2π.
+// splinter has no periodic splines, so this is an approximation: the samples within periodicMargin of a period of
+// either end of the data are copied, shifted by one period, to the other side of the seam, and the builder is
+// recreated from the augmented samples. The fit then sees the same data on both sides of the seam and is continuous
+// and smooth across it to within the fitting error, but not exactly periodic. Its domain extends beyond one period, so
+// inputs should be reduced to the original period before evaluating.
+//
+// The builder's settings are kept; copies get the weight of the sample they copy, and bounds on dim are widened to
+// contain them. Call Periodic once per variable, after setting weights and bounds.
+func (builder *BSplineBuilder) Periodic(dim int, period float64) error {
+	if len(builder.y) == 0 {
+		return ErrNoSamples
+	}
+	if dim < 0 || dim >= len(builder.x[0]) {
+		return ErrInvalidVariable
+	}
+
+	lo, hi := builder.x[0][dim], builder.x[0][dim]
+	for _, row := range builder.x {
+		lo, hi = math.Min(lo, row[dim]), math.Max(hi, row[dim])
+	}
+	if !(period > 0) || hi-lo > period {
+		return ErrInvalidPeriod
+	}
+
+	weighted := len(builder.config.Weights) == len(builder.y)
+	margin := periodicMargin * period
+	x := append([][]float64{}, builder.x...)
+	y := append([]float64{}, builder.y...)
+	var weights []float64
+	if weighted {
+		weights = append(weights, builder.config.Weights...)
+	}
+	for i, row := range builder.x {
+		for _, shift := range []float64{period, -period} {
+			if (shift > 0 && row[dim] < lo+margin) || (shift < 0 && row[dim] > hi-margin) {
+				wrapped := append([]float64{}, row...)
+				wrapped[dim] += shift
+				x = append(x, wrapped)
+				y = append(y, builder.y[i])
+				if weighted {
+					weights = append(weights, builder.config.Weights[i])
+				}
+			}
+		}
+	}
+
+	dt, err := newDataTableFromSamples(x, y)
+	if err != nil {
+		return err
+	}
+	defer dt.Free()
+
+	cfg := builder.config
+	if weighted {
+		// put the weights in the order of the table, which is sorted and keeps the first of duplicate samples
+		order := make([]int, len(x))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(i, j int) bool { return lessX(x[order[i]], x[order[j]]) })
+		cfg.Weights = nil
+		for k, i := range order {
+			if k == 0 || !equalX(x[order[k-1]], x[i]) {
+				cfg.Weights = append(cfg.Weights, weights[i])
+			}
+		}
+	}
+	if len(cfg.Bounds) > 0 {
+		cfg.Bounds = append([][]float64{}, cfg.Bounds...)
+		// the copies reach down to hi-margin-period and up to lo+margin+period
+		cfg.Bounds[dim] = []float64{
+			math.Min(cfg.Bounds[dim][0], hi-margin-period),
+			math.Max(cfg.Bounds[dim][1], lo+margin+period),
+		}
+	}
+
+	err = builder.replaceTable(dt)
+	if err != nil {
+		return err
+	}
+	return builder.Configure(cfg)
+}
@@ -0,0 +1,100 @@
+package splinter
+
+import (
+	"math"
+	"testing"
+)
+
+// seamJump returns how much the spline's value and slope differ between the two sides of the seam at 0 = 2π.
+func seamJump(t *testing.T, bs *BSpline) (value, slope float64) {
+	const h = 1e-4
+	at := func(x float64) float64 {
+		v, err := bs.Eval(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	end := 2 * math.Pi
+	value = math.Abs(at(0) - at(end))
+	slope = math.Abs((at(h)-at(0))/h - (at(end)-at(end-h))/h)
+	return value, slope
+}
+
+func TestPeriodic(t *testing.T) {
+	// a periodic signal sampled over one period, with the last sample just before the seam
+	x := linspace(0, 2*math.Pi, 41)[:40]
+	y := make([]float64, len(x))
+	for i, v := range x {
+		y[i] = math.Cos(v) + 0.5*math.Sin(2*v)
+	}
+	dt, err := NewDataTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dt.AddColumns(x, y); err != nil {
+		t.Fatal(err)
+	}
+
+	builder, err := NewBSplineBuilder(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.Configure(BuilderConfig{Smoothing: SmoothingPspline, Alpha: 0.01, Bounds: [][]float64{{0, 2 * math.Pi}}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.Periodic(0, 2*math.Pi); err != nil {
+		t.Fatal(err)
+	}
+	// 10 samples copied to each side
+	if len(builder.y) != 60 || len(builder.config.Weights) != 0 || builder.config.Smoothing != SmoothingPspline {
+		t.Errorf("unexpected builder state: %d samples, config %+v", len(builder.y), builder.config)
+	}
+
+	bs, err := builder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, slope := seamJump(t, bs)
+	if value > 1e-3 || slope > 1e-2 {
+		t.Errorf("expected a smooth seam, got jumps of %v in value and %v in slope", value, slope)
+	}
+
+	if err := builder.Periodic(1, 2*math.Pi); err != ErrInvalidVariable {
+		t.Errorf("expected ErrInvalidVariable, got %v", err)
+	}
+	if err := builder.Periodic(0, 0); err != ErrInvalidPeriod {
+		t.Errorf("expected ErrInvalidPeriod, got %v", err)
+	}
+	if err := builder.Periodic(0, 1); err != ErrInvalidPeriod {
+		t.Errorf("expected ErrInvalidPeriod for a period shorter than the data, got %v", err)
+	}
+}
+
+func TestPeriodicKeepsWeights(t *testing.T) {
+	dt, err := NewDataTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dt.AddColumns([]float64{0, 1, 2, 3}, []float64{0, 1, 0, -1}); err != nil {
+		t.Fatal(err)
+	}
+	builder, err := NewBSplineBuilder(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.Weights([]float64{1, 2, 3, 4}); err != nil {
+		t.Fatal(err)
+	}
+
+	// with period 4 and a margin of 1, 0 is copied to 4 and 3 to -1
+	if err := builder.Periodic(0, 4); err != nil {
+		t.Fatal(err)
+	}
+	expected := []float64{4, 1, 2, 3, 4, 1}
+	for i, w := range expected {
+		if builder.config.Weights[i] != w {
+			t.Fatalf("expected weights %v, got %v", expected, builder.config.Weights)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package splinter
+
+import (
+	"sync"
+	"time"
+)
+
+// memoryPressureInterval is how often the hook set by SetMemoryPressureHook samples the memory of the process.
+var memoryPressureInterval = time.Second
+
+var (
+	memoryPressureMu   sync.Mutex
+	memoryPressureStop chan struct{}
+)
+
+// SetMemoryPressureHook calls cb whenever the resident set size of the process rises above threshold bytes, so that
+// callers can Free splines or run runtime.GC before native memory balloons: splinter's allocations are invisible to
+// the Go garbage collector, which therefore sees no reason to run the finalizers that would release them. The memory is
+// sampled every second from a background goroutine, and cb runs on that goroutine once per crossing, not again until
+// the memory has dropped below threshold. A new hook replaces the previous one, and a threshold <= 0
+// or a nil cb removes it.
+//
+// This is best effort: the resident set size includes the Go heap and everything else in the process, memory can
+// spike and fall between two samples unnoticed, and the hook never fires where the resident set size cannot be read
+// (currently anywhere but Linux, see BuildProfile).
+func SetMemoryPressureHook(threshold int, cb func()) {
+	memoryPressureMu.Lock()
+	defer memoryPressureMu.Unlock()
+
+	if memoryPressureStop != nil {
+		close(memoryPressureStop)
+		memoryPressureStop = nil
+	}
+	if threshold <= 0 || cb == nil {
+		return
+	}
+
+	stop := make(chan struct{})
+	memoryPressureStop = stop
+	go watchMemoryPressure(int64(threshold), cb, memoryPressureInterval, stop)
+}
+
+func watchMemoryPressure(threshold int64, cb func(), interval time.Duration, stop chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	above := false
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		resident, ok := residentBytes()
+		if !ok {
+			continue
+		}
+		if resident > threshold && !above {
+			cb()
+		}
+		above = resident > threshold
+	}
+}
@@ -0,0 +1,30 @@
+package splinter
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestSetMemoryPressureHook(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the resident set size is only read on linux")
+	}
+
+	defer func(interval time.Duration) { memoryPressureInterval = interval }(memoryPressureInterval)
+	memoryPressureInterval = time.Millisecond
+
+	// any process is above one byte, so the hook fires once and stays quiet while the memory stays above
+	calls := make(chan struct{}, 10)
+	SetMemoryPressureHook(1, func() { calls <- struct{}{} })
+	select {
+	case <-calls:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the hook to fire")
+	}
+	time.Sleep(20 * time.Millisecond)
+	SetMemoryPressureHook(0, nil)
+	if len(calls) != 0 {
+		t.Errorf("expected a single call per crossing, got %d more", len(calls))
+	}
+}
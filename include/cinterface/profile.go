@@ -0,0 +1,36 @@
+package splinter
+
+import (
+	"time"
+)
+
+// BuildProfile describes the cost of a build, see BSplineBuilder.BuildProfiled.
+type BuildProfile struct {
+	// Duration is the wall-clock time of the build.
+	Duration time.Duration
+
+	// NativeMemory estimates the memory in bytes taken by the build, as the growth of the resident set size of the
+	// process over it, or -1 where the resident set size cannot be read (currently anywhere but Linux).
+	NativeMemory int64
+}
+
+// BuildProfiled builds the spline like Build and reports the time and memory the build took, for sizing workers that
+// fit many splines. The resident set size is sampled once before and once after the build, which adds no measurable
+// overhead, but also makes the memory figure an estimate: it counts what the spline and splinter's allocator still hold
+// after the build, not the peak of the temporaries freed before it returns, and it includes allocations made
+// concurrently by other goroutines.
+func (builder *BSplineBuilder) BuildProfiled() (*BSpline, BuildProfile, error) {
+	before, ok := residentBytes()
+
+	start := time.Now()
+	bs, err := builder.Build()
+	profile := BuildProfile{Duration: time.Since(start), NativeMemory: -1}
+
+	if after, ok2 := residentBytes(); ok && ok2 {
+		profile.NativeMemory = 0
+		if after > before {
+			profile.NativeMemory = after - before
+		}
+	}
+	return bs, profile, err
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+// +build !linux
+
+package splinter
+
+// residentBytes reports that the resident set size is unavailable on this platform.
+func residentBytes() (int64, bool) {
+	return 0, false
+}
@@ -0,0 +1,32 @@
+package splinter
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestBuildProfiled(t *testing.T) {
+	dt, err := newTestSpline2D(t).Resample([][]float64{linspace(0, 1, 21), linspace(0, 1, 21)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	builder, err := NewBSplineBuilder(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bs, profile, err := builder.BuildProfiled()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := bs.Eval(0.5, 0.5); err != nil || !almostEqual(v, 0.5, 1e-9) {
+		t.Errorf("unexpected value %v, %v", v, err)
+	}
+
+	if profile.Duration <= 0 {
+		t.Errorf("expected a positive duration, got %v", profile.Duration)
+	}
+	if runtime.GOOS == "linux" && profile.NativeMemory < 0 {
+		t.Errorf("expected a memory estimate on linux, got %d", profile.NativeMemory)
+	}
+}
@@ -0,0 +1,177 @@
+package splinter
+
+import (
+	"math"
+)
+
+// gaussLegendre returns the nodes and weights of the n-point Gauss-Legendre rule on [-1, 1], which integrates
+// polynomials of degree up to 2n-1 exactly.
+func gaussLegendre(n int) (nodes, weights []float64) {
+	nodes = make([]float64, n)
+	weights = make([]float64, n)
+	for i := 0; i < (n+1)/2; i++ {
+		// Newton's method on the Legendre polynomial P_n, starting from the Chebyshev approximation of the root
+		x := math.Cos(math.Pi * (float64(i) + 0.75) / (float64(n) + 0.5))
+		var dp float64
+		for iter := 0; iter < 100; iter++ {
+			p0, p1 := 1.0, x
+			for k := 2; k <= n; k++ {
+				p0, p1 = p1, (float64(2*k-1)*x*p1-float64(k-1)*p0)/float64(k)
+			}
+			dp = float64(n) * (x*p1 - p0) / (x*x - 1)
+			dx := p1 / dp
+			x -= dx
+			if math.Abs(dx) < 1e-15 {
+				break
+			}
+		}
+
+		w := 2 / ((1 - x*x) * dp * dp)
+		nodes[i], nodes[n-1-i] = -x, x
+		weights[i], weights[n-1-i] = w, w
+	}
+	return nodes, weights
+}
+
+// spanQuadrature returns the nodes and weights of the composite rule applying the n-point Gauss-Legendre rule on
+// every non-empty knot span. Spline pieces are polynomials on each span, so the rule is exact for them when n is
+// large enough.
+func spanQuadrature(knots []float64, n int) (nodes, weights []float64) {
+	gaussNodes, gaussWeights := gaussLegendre(n)
+	for i := 0; i+1 < len(knots); i++ {
+		a, b := knots[i], knots[i+1]
+		if a == b {
+			continue
+		}
+
+		for j, t := range gaussNodes {
+			nodes = append(nodes, (a+b)/2+(b-a)/2*t)
+			weights = append(weights, (b-a)/2*gaussWeights[j])
+		}
+	}
+	return nodes, weights
+}
+
+// quadratureGrid returns the axes of a tensor product quadrature rule over the spline's domain, together with the
+// matching weights per axis. Each axis uses degree+1+extra Gauss-Legendre points per knot span, which integrates
+// products of two pieces of the spline and their derivatives exactly when extra is 0. The rule's points and weights
+// are enumerated with gridPoint on axes and weights respectively.
+func (bs *BSpline) quadratureGrid(extra int) (axes, weights [][]float64, err error) {
+	knotVectors, err := bs.KnotVectors()
+	if err != nil {
+		return nil, nil, err
+	}
+	degrees, err := bs.Degrees()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	axes = make([][]float64, len(knotVectors))
+	weights = make([][]float64, len(knotVectors))
+	for i, knots := range knotVectors {
+		axes[i], weights[i] = spanQuadrature(knots, degrees[i]+1+extra)
+	}
+	return axes, weights, nil
+}
+
+// maxSimpsonDepth bounds the recursion of adaptiveSimpson.
+const maxSimpsonDepth = 50
+
+// adaptiveSimpson integrates f over [a, b] to within roughly tol, recursively bisecting intervals where Simpson's
+// rule and its composite over the two halves disagree.
+func adaptiveSimpson(f func(float64) (float64, error), a, b, tol float64) (float64, error) {
+	fa, err := f(a)
+	if err != nil {
+		return 0, err
+	}
+	fm, err := f((a + b) / 2)
+	if err != nil {
+		return 0, err
+	}
+	fb, err := f(b)
+	if err != nil {
+		return 0, err
+	}
+	whole := (b - a) / 6 * (fa + 4*fm + fb)
+	return simpsonStep(f, a, b, fa, fm, fb, whole, tol, maxSimpsonDepth)
+}
+
+func simpsonStep(f func(float64) (float64, error), a, b, fa, fm, fb, whole, tol float64, depth int) (float64, error) {
+	m := (a + b) / 2
+	flm, err := f((a + m) / 2)
+	if err != nil {
+		return 0, err
+	}
+	frm, err := f((m + b) / 2)
+	if err != nil {
+		return 0, err
+	}
+
+	left := (m - a) / 6 * (fa + 4*flm + fm)
+	right := (b - m) / 6 * (fm + 4*frm + fb)
+	diff := left + right - whole
+	if depth == 0 || math.Abs(diff) <= 15*tol {
+		// Richardson extrapolation
+		return left + right + diff/15, nil
+	}
+
+	l, err := simpsonStep(f, a, m, fa, flm, fm, left, tol/2, depth-1)
+	if err != nil {
+		return 0, err
+	}
+	r, err := simpsonStep(f, m, b, fm, frm, fb, right, tol/2, depth-1)
+	if err != nil {
+		return 0, err
+	}
+	return l + r, nil
+}
+
+// integrateBreakpoints integrates f over the intervals between consecutive distinct breakpoints with adaptiveSimpson,
+// sharing the tolerance between them in proportion to their length.
+func integrateBreakpoints(f func(float64) (float64, error), breakpoints []float64, tol float64) (float64, error) {
+	width := breakpoints[len(breakpoints)-1] - breakpoints[0]
+	total := 0.0
+	for i := 0; i+1 < len(breakpoints); i++ {
+		a, b := breakpoints[i], breakpoints[i+1]
+		if a == b {
+			continue
+		}
+
+		v, err := adaptiveSimpson(f, a, b, tol*(b-a)/width)
+		if err != nil {
+			return 0, err
+		}
+		total += v
+	}
+	return total, nil
+}
+
+// IntegralAdaptive integrates the spline over its domain with adaptive Simpson quadrature on Eval, to within about
+// tol. The knots are used as initial breakpoints, so each interval only covers smooth polynomial pieces. Only
+// splines with one or two variables are supported (ErrUnsupportedDim otherwise); in two variables the quadrature is
+// nested, integrating over the second variable for each point the first needs, which takes many evaluations for
+// small tolerances.
+func (bs *BSpline) IntegralAdaptive(tol float64) (float64, error) {
+	if !(tol > 0) {
+		return 0, ErrInvalidTolerance
+	}
+
+	knotVectors, err := bs.KnotVectors()
+	if err != nil {
+		return 0, err
+	}
+
+	switch len(knotVectors) {
+	case 1:
+		f := func(x float64) (float64, error) { return bs.Eval(x) }
+		return integrateBreakpoints(f, knotVectors[0], tol)
+	case 2:
+		width := knotVectors[0][len(knotVectors[0])-1] - knotVectors[0][0]
+		inner := func(x0 float64) (float64, error) {
+			f := func(x1 float64) (float64, error) { return bs.Eval(x0, x1) }
+			return integrateBreakpoints(f, knotVectors[1], tol/(2*width))
+		}
+		return integrateBreakpoints(inner, knotVectors[0], tol/2)
+	}
+	return 0, ErrUnsupportedDim
+}
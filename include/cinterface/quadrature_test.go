@@ -0,0 +1,100 @@
+package splinter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGaussLegendre(t *testing.T) {
+	for n := 1; n <= 8; n++ {
+		nodes, weights := gaussLegendre(n)
+
+		// exact for monomials up to degree 2n-1
+		for d := 0; d < 2*n; d++ {
+			sum := 0.0
+			for i, x := range nodes {
+				sum += weights[i] * math.Pow(x, float64(d))
+			}
+
+			expected := 0.0
+			if d%2 == 0 {
+				expected = 2 / float64(d+1)
+			}
+			if !almostEqual(sum, expected, 1e-12) {
+				t.Errorf("n=%d, degree %d: expected %v, got %v", n, d, expected, sum)
+			}
+		}
+	}
+}
+
+func TestSpanQuadrature(t *testing.T) {
+	nodes, weights := spanQuadrature([]float64{0, 0, 1, 1, 3}, 2)
+	if len(nodes) != 4 {
+		t.Fatalf("expected 2 points on each of 2 spans, got %d", len(nodes))
+	}
+
+	sum := 0.0
+	for i, x := range nodes {
+		sum += weights[i] * x * x * x
+	}
+	if !almostEqual(sum, 81.0/4, 1e-12) {
+		t.Errorf("expected %v, got %v", 81.0/4, sum)
+	}
+}
+
+// exactIntegral integrates a spline with the tensor product Gauss-Legendre rule, which is exact for it.
+func exactIntegral(t *testing.T, bs *BSpline) float64 {
+	axes, weights, err := bs.quadratureGrid(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values, err := bs.EvalGrid(axes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	total := 0.0
+	w := make([]float64, len(axes))
+	for k, v := range values {
+		gridPoint(weights, k, w)
+		for _, wi := range w {
+			v *= wi
+		}
+		total += v
+	}
+	return total
+}
+
+func TestIntegralAdaptive(t *testing.T) {
+	wiggly, err := Fit(noisyTable(t), BuilderConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		bs       *BSpline
+		expected float64
+	}{
+		{newTestSpline1D(t), 8.0 / 3},
+		{newTestSpline2D(t), 1.0/3 + 1.0/4},
+		{wiggly, exactIntegral(t, wiggly)},
+	}
+	for i, c := range cases {
+		v, err := c.bs.IntegralAdaptive(1e-8)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !almostEqual(v, c.expected, 1e-7) {
+			t.Errorf("case %d: expected %v, got %v", i, c.expected, v)
+		}
+	}
+
+	axis := linspace(0, 1, 4)
+	threeD := buildTestSpline(t, [][]float64{axis, axis, axis}, func(x []float64) float64 { return x[0] + x[1] + x[2] })
+	if _, err := threeD.IntegralAdaptive(1e-6); err != ErrUnsupportedDim {
+		t.Errorf("expected ErrUnsupportedDim, got %v", err)
+	}
+	if _, err := wiggly.IntegralAdaptive(0); err != ErrInvalidTolerance {
+		t.Errorf("expected ErrInvalidTolerance, got %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+package splinter
+
+type SequenceKind int
+
+const (
+	SequenceHalton SequenceKind = 0
+	SequenceSobol               = 1
+)
+
+// sobolBits is the number of bits of precision of the generated Sobol points.
+const sobolBits = 32
+
+// sobolParams holds the primitive polynomial degree s, its coefficients a and the initial direction numbers m of the
+// Sobol dimensions after the first, from Joe and Kuo's new-joe-kuo-6.21201 table.
+var sobolParams = []struct {
+	s, a uint
+	m    []uint32
+}{
+	{1, 0, []uint32{1}},
+	{2, 1, []uint32{1, 3}},
+	{3, 1, []uint32{1, 3, 1}},
+	{3, 2, []uint32{1, 1, 1}},
+	{4, 1, []uint32{1, 1, 3, 3}},
+	{4, 4, []uint32{1, 3, 5, 13}},
+	{5, 2, []uint32{1, 1, 5, 5, 17}},
+	{5, 4, []uint32{1, 1, 5, 5, 5}},
+	{5, 7, []uint32{1, 1, 7, 11, 19}},
+}
+
+// sobolDirections returns the direction numbers of Sobol dimension dim, indexed from 1.
+func sobolDirections(dim int) []uint32 {
+	v := make([]uint32, sobolBits+1)
+	if dim == 0 {
+		for i := 1; i <= sobolBits; i++ {
+			v[i] = 1 << uint(sobolBits-i)
+		}
+		return v
+	}
+
+	p := sobolParams[dim-1]
+	s := int(p.s)
+	for i := 1; i <= sobolBits; i++ {
+		if i <= s {
+			v[i] = p.m[i-1] << uint(sobolBits-i)
+			continue
+		}
+
+		v[i] = v[i-s] ^ (v[i-s] >> p.s)
+		for k := 1; k < s; k++ {
+			v[i] ^= ((uint32(p.a) >> uint(s-1-k)) & 1) * v[i-k]
+		}
+	}
+	return v
+}
+
+// sobolPoints returns the first n points of the dim-dimensional Sobol sequence in [0, 1)^dim, skipping the origin.
+func sobolPoints(n, dim int) ([][]float64, error) {
+	if dim > len(sobolParams)+1 {
+		return nil, ErrUnsupportedDim
+	}
+
+	directions := make([][]uint32, dim)
+	for j := range directions {
+		directions[j] = sobolDirections(j)
+	}
+
+	points := make([][]float64, n)
+	x := make([]uint32, dim)
+	for i := range points {
+		// Gray code ordering: flip the direction number of the lowest zero bit of the index
+		c := 1
+		for value := uint(i); value&1 == 1; value >>= 1 {
+			c++
+		}
+
+		points[i] = make([]float64, dim)
+		for j := range x {
+			x[j] ^= directions[j][c]
+			points[i][j] = float64(x[j]) / (1 << sobolBits)
+		}
+	}
+	return points, nil
+}
+
+// haltonPoints returns the first n points of the dim-dimensional Halton sequence in [0, 1)^dim, skipping the origin.
+func haltonPoints(n, dim int) [][]float64 {
+	bases := make([]int, 0, dim)
+	for candidate := 2; len(bases) < dim; candidate++ {
+		prime := true
+		for _, b := range bases {
+			if candidate%b == 0 {
+				prime = false
+				break
+			}
+		}
+		if prime {
+			bases = append(bases, candidate)
+		}
+	}
+
+	points := make([][]float64, n)
+	for i := range points {
+		points[i] = make([]float64, dim)
+		for j, b := range bases {
+			// radical inverse of i+1 in base b
+			f, r := 1.0, 0.0
+			for k := i + 1; k > 0; k /= b {
+				f /= float64(b)
+				r += f * float64(k%b)
+			}
+			points[i][j] = r
+		}
+	}
+	return points
+}
+
+// EvalQuasiRandom evaluates the spline at the first n points of a Halton or Sobol low-discrepancy sequence scaled to
+// its domain. The mean of the values is a quasi-Monte Carlo estimate of the spline's mean over the domain. Sobol
+// sequences are available for up to 10 variables.
+func (bs *BSpline) EvalQuasiRandom(n int, seq SequenceKind) (points [][]float64, values []float64, err error) {
+	if n < 1 {
+		return nil, nil, ErrInvalidCount
+	}
+
+	domain, err := bs.GetDomain()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch seq {
+	case SequenceHalton:
+		points = haltonPoints(n, len(domain))
+	case SequenceSobol:
+		points, err = sobolPoints(n, len(domain))
+		if err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, ErrInvalidSequence
+	}
+
+	for _, point := range points {
+		for j, u := range point {
+			point[j] = domain[j][0] + u*(domain[j][1]-domain[j][0])
+		}
+	}
+
+	values, err = bs.evalSamples(points)
+	if err != nil {
+		return nil, nil, err
+	}
+	return points, values, nil
+}
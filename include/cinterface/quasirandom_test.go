@@ -0,0 +1,65 @@
+package splinter
+
+import (
+	"testing"
+)
+
+func TestLowDiscrepancySequences(t *testing.T) {
+	halton := haltonPoints(4, 2)
+	expectedHalton := [][]float64{{0.5, 1.0 / 3}, {0.25, 2.0 / 3}, {0.75, 1.0 / 9}, {0.125, 4.0 / 9}}
+	for i, point := range expectedHalton {
+		for j, v := range point {
+			if !almostEqual(halton[i][j], v, 1e-12) {
+				t.Errorf("halton point %d: expected %v, got %v", i, point, halton[i])
+			}
+		}
+	}
+
+	sobol, err := sobolPoints(4, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedSobol := [][]float64{{0.5, 0.5}, {0.75, 0.25}, {0.25, 0.75}, {0.375, 0.375}}
+	for i, point := range expectedSobol {
+		for j, v := range point {
+			if sobol[i][j] != v {
+				t.Errorf("sobol point %d: expected %v, got %v", i, point, sobol[i])
+			}
+		}
+	}
+
+	if _, err := sobolPoints(1, 11); err != ErrUnsupportedDim {
+		t.Errorf("expected ErrUnsupportedDim, got %v", err)
+	}
+}
+
+func TestEvalQuasiRandom(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	for _, seq := range []SequenceKind{SequenceHalton, SequenceSobol} {
+		points, values, err := bs.EvalQuasiRandom(1024, seq)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mean := 0.0
+		for i, v := range values {
+			if !almostEqual(v, bilinearish(points[i]), 1e-9) {
+				t.Fatalf("at %v: expected %v, got %v", points[i], bilinearish(points[i]), v)
+			}
+			mean += v / float64(len(values))
+		}
+
+		// mean of x0^2 + x0*x1 over the unit square
+		if !almostEqual(mean, 1.0/3+1.0/4, 1e-2) {
+			t.Errorf("sequence %d: expected mean near %v, got %v", seq, 1.0/3+1.0/4, mean)
+		}
+	}
+
+	if _, _, err := bs.EvalQuasiRandom(10, SequenceKind(7)); err != ErrInvalidSequence {
+		t.Errorf("expected ErrInvalidSequence, got %v", err)
+	}
+	if _, _, err := bs.EvalQuasiRandom(0, SequenceHalton); err != ErrInvalidCount {
+		t.Errorf("expected ErrInvalidCount, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package splinter
+
+// reknotOversampling is the number of grid points per basis function Reknot samples the spline at when the knots do
+// not follow the samples, so that the refit is a least squares approximation rather than an interpolation.
+const reknotOversampling = 4
+
+// reknotAlpha is the regularization Reknot uses for least squares refits. splinter only solves square systems
+// without smoothing, so a ridge term small enough not to affect the result is added instead.
+const reknotAlpha = 1e-10
+
+// Reknot approximates the spline with a new one using the given knot spacing and countPerDim basis functions per
+// variable, without needing the original data. The spline is resampled on a grid over its domain and the samples are
+// refitted with the builder's defaults, so the result is cubic whatever the degree of bs.
+//
+// With KnotSpacingAsSampled the grid has countPerDim[i] evenly spaced points in variable i and the new spline
+// interpolates them. With KnotSpacingEquidistant the grid has reknotOversampling times as many points and the new
+// spline is their least squares fit on equidistant knots; other spacings give ErrInvalidSpacing as they do not let
+// the number of basis functions be chosen. Either way the result is exact only if bs lies
+// in the new spline space (a cubic or lower polynomial, say); otherwise the error is that of approximating bs with a
+// cubic spline on the new knots, which shrinks with the fourth power of the knot spacing for smooth splines. Compare
+// the two with MaxErrorVsFunc to check it.
+func (bs *BSpline) Reknot(spacing KnotSpacing, countPerDim []int) (*BSpline, error) {
+	domain, err := bs.GetDomain()
+	if err != nil {
+		return nil, err
+	}
+	if len(countPerDim) != len(domain) {
+		return nil, ErrDimensionMismatch
+	}
+	for _, count := range countPerDim {
+		// a cubic needs at least 4 basis functions
+		if count < 4 {
+			return nil, ErrInvalidCount
+		}
+	}
+
+	cfg := BuilderConfig{KnotSpacing: spacing}
+	oversampling := 1
+	switch spacing {
+	case KnotSpacingAsSampled:
+	case KnotSpacingEquidistant:
+		// splinter's equidistant knot vectors have two basis functions fewer than requested
+		cfg.NumBasisFunctions = make([]int, len(countPerDim))
+		for i, count := range countPerDim {
+			cfg.NumBasisFunctions[i] = count + 2
+		}
+		cfg.Smoothing = SmoothingIdentity
+		cfg.Alpha = reknotAlpha
+		oversampling = reknotOversampling
+	default:
+		return nil, ErrInvalidSpacing
+	}
+
+	axes := make([][]float64, len(domain))
+	for i, d := range domain {
+		axes[i] = linspace(d[0], d[1], oversampling*countPerDim[i])
+	}
+	dt, err := bs.Resample(axes)
+	if err != nil {
+		return nil, err
+	}
+	defer dt.Free()
+
+	return Fit(dt, cfg)
+}
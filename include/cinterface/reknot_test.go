@@ -0,0 +1,67 @@
+package splinter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestReknot(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	for _, spacing := range []KnotSpacing{KnotSpacingAsSampled, KnotSpacingEquidistant} {
+		reknotted, err := bs.Reknot(spacing, []int{5, 6})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		knotVectors, err := reknotted.KnotVectors()
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 4 repeated end knots on each side plus count-4 interior knots
+		if len(knotVectors[0]) != 9 || len(knotVectors[1]) != 10 {
+			t.Errorf("spacing %v: unexpected knot vector lengths %d and %d", spacing, len(knotVectors[0]), len(knotVectors[1]))
+		}
+
+		// the spline is a polynomial of degree 2, so the cubic refit reproduces it
+		maxErr, _, err := reknotted.MaxErrorVsFunc(bilinearish, [][]float64{linspace(0, 1, 13), linspace(0, 1, 13)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if maxErr > 1e-9 {
+			t.Errorf("spacing %v: expected an exact refit, got error %v", spacing, maxErr)
+		}
+	}
+
+	// a wiggly spline is only approximated, better with more knots
+	wiggly := buildTestSpline(t, [][]float64{linspace(0, 3, 61)}, func(x []float64) float64 { return math.Sin(4 * x[0]) })
+	target := func(x []float64) float64 {
+		v, _ := wiggly.Eval(x...)
+		return v
+	}
+	var errs []float64
+	for _, count := range []int{8, 16} {
+		reknotted, err := wiggly.Reknot(KnotSpacingEquidistant, []int{count})
+		if err != nil {
+			t.Fatal(err)
+		}
+		maxErr, _, err := reknotted.MaxErrorVsFunc(target, [][]float64{linspace(0, 3, 301)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		errs = append(errs, maxErr)
+	}
+	if errs[1] >= errs[0] || errs[1] > 0.01 {
+		t.Errorf("expected the error to shrink with more knots, got %v", errs)
+	}
+
+	if _, err := bs.Reknot(KnotSpacingEquidistant, []int{5}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := bs.Reknot(KnotSpacingEquidistant, []int{5, 3}); err != ErrInvalidCount {
+		t.Errorf("expected ErrInvalidCount, got %v", err)
+	}
+	if _, err := bs.Reknot(KnotSpacingExperimental, []int{5, 6}); err != ErrInvalidSpacing {
+		t.Errorf("expected ErrInvalidSpacing, got %v", err)
+	}
+}
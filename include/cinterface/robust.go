@@ -0,0 +1,97 @@
+package splinter
+
+import (
+	"math"
+)
+
+// robustWeightTol is the largest change in any Huber weight between two iterations at which RobustFit considers the
+// weights converged.
+const robustWeightTol = 1e-6
+
+// RobustFit fits a spline with the Huber loss, which is quadratic for residuals up to delta and linear beyond, so
+// outliers pull on the fit far less than with least squares. delta is in the units of the responses; 1.345 times the
+// noise standard deviation is the usual choice.
+//
+// splinter only minimizes weighted least squares, so the Huber fit is found by iteratively reweighted least squares:
+// starting from an ordinary fit, each iteration gives sample i the weight min(1, delta/|rᵢ|) for its residual rᵢ of
+// the previous fit (times the builder's own weight for it, if any) and refits. Iteration stops when no weight changes
+// by more than robustWeightTol, or after iters refits. The fit of the last iteration is returned.
+//
+// Weights are only used by P-splines, so the builder must use SmoothingPspline; ErrNotPspline is returned otherwise.
+// The builder's weights are restored before returning.
+func (builder *BSplineBuilder) RobustFit(iters int, delta float64) (*BSpline, error) {
+	if iters <= 0 {
+		return nil, ErrInvalidCount
+	}
+	if !(delta > 0) {
+		return nil, ErrInvalidDelta
+	}
+	if builder.config.Smoothing != SmoothingPspline {
+		return nil, ErrNotPspline
+	}
+	if len(builder.y) == 0 {
+		return nil, ErrNoSamples
+	}
+
+	prior := builder.config.Weights
+	base := prior
+	if len(base) != len(builder.y) {
+		base = make([]float64, len(builder.y))
+		for i := range base {
+			base[i] = 1
+		}
+	}
+	defer func() {
+		// unit weights are what splinter uses when none are set
+		builder.Weights(base)
+		builder.config.Weights = prior
+	}()
+
+	err := builder.Weights(base)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	huber := make([]float64, len(builder.y))
+	for i := range huber {
+		huber[i] = 1
+	}
+	weights := make([]float64, len(builder.y))
+	for iter := 0; iter < iters; iter++ {
+		fitted, err := bs.evalSamples(builder.x)
+		if err != nil {
+			bs.Free()
+			return nil, err
+		}
+
+		change := 0.0
+		for i, f := range fitted {
+			h := 1.0
+			if r := math.Abs(builder.y[i] - f); r > delta {
+				h = delta / r
+			}
+			change = math.Max(change, math.Abs(h-huber[i]))
+			huber[i] = h
+			weights[i] = base[i] * h
+		}
+		if change <= robustWeightTol {
+			break
+		}
+
+		err = builder.Weights(weights)
+		if err != nil {
+			bs.Free()
+			return nil, err
+		}
+		bs.Free()
+		bs, err = builder.Build()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return bs, nil
+}
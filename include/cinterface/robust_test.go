@@ -0,0 +1,71 @@
+package splinter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRobustFit(t *testing.T) {
+	x := linspace(0, 3, 31)
+	y := make([]float64, len(x))
+	for i, v := range x {
+		y[i] = math.Sin(v)
+	}
+	y[15] += 5
+
+	dt, err := NewDataTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dt.AddColumns(x, y); err != nil {
+		t.Fatal(err)
+	}
+	builder, err := NewBSplineBuilder(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := builder.Configure(BuilderConfig{Smoothing: SmoothingPspline, Alpha: 0.1}); err != nil {
+		t.Fatal(err)
+	}
+
+	plain, err := builder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	robust, err := builder.RobustFit(50, 0.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// compare the fits with the clean function next to the outlier
+	maxErr := func(bs *BSpline) float64 {
+		worst := 0.0
+		for _, v := range linspace(1.3, 1.7, 9) {
+			f, err := bs.Eval(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			worst = math.Max(worst, math.Abs(f-math.Sin(v)))
+		}
+		return worst
+	}
+	if plainErr, robustErr := maxErr(plain), maxErr(robust); robustErr > plainErr/5 {
+		t.Errorf("expected the robust fit to resist the outlier, got error %v against %v", robustErr, plainErr)
+	}
+	if builder.config.Weights != nil {
+		t.Errorf("expected the builder's weights to be restored, got %v", builder.config.Weights)
+	}
+
+	if _, err := builder.RobustFit(0, 0.1); err != ErrInvalidCount {
+		t.Errorf("expected ErrInvalidCount, got %v", err)
+	}
+	if _, err := builder.RobustFit(10, 0); err != ErrInvalidDelta {
+		t.Errorf("expected ErrInvalidDelta, got %v", err)
+	}
+	if err := builder.Smoothing(SmoothingIdentity); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := builder.RobustFit(10, 0.1); err != ErrNotPspline {
+		t.Errorf("expected ErrNotPspline, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package splinter
+
+import (
+	"math"
+)
+
+// RunningStats evaluates a spline on a stream of inputs and keeps running statistics of the outputs in constant
+// memory. It is not safe for concurrent use.
+type RunningStats struct {
+	bs    *BSpline
+	count int
+	mean  float64
+	// m2 is the sum of squared deviations from the mean, updated with Welford's algorithm
+	m2       float64
+	min, max float64
+}
+
+// StatsSnapshot holds the statistics of the outputs observed by a RunningStats. Variance is the sample variance,
+// which is 0 until two outputs have been observed; Min and Max are NaN until the first one.
+type StatsSnapshot struct {
+	Count    int
+	Mean     float64
+	Min      float64
+	Max      float64
+	Variance float64
+}
+
+// NewRunningStats returns a RunningStats evaluating bs.
+func NewRunningStats(bs *BSpline) (*RunningStats, error) {
+	if bs == nil {
+		return nil, ErrInvalidNil
+	}
+	return &RunningStats{bs: bs, min: math.NaN(), max: math.NaN()}, nil
+}
+
+// Observe evaluates the spline at the given point and adds the output to the statistics. Points the spline fails to
+// evaluate are not counted.
+func (rs *RunningStats) Observe(vals ...float64) error {
+	v, err := rs.bs.Eval(vals...)
+	if err != nil {
+		return err
+	}
+
+	rs.count++
+	delta := v - rs.mean
+	rs.mean += delta / float64(rs.count)
+	rs.m2 += delta * (v - rs.mean)
+	if rs.count == 1 {
+		rs.min, rs.max = v, v
+	} else {
+		rs.min, rs.max = math.Min(rs.min, v), math.Max(rs.max, v)
+	}
+	return nil
+}
+
+// Snapshot returns the statistics of the outputs observed so far.
+func (rs *RunningStats) Snapshot() StatsSnapshot {
+	variance := 0.0
+	if rs.count > 1 {
+		variance = rs.m2 / float64(rs.count-1)
+	}
+	return StatsSnapshot{Count: rs.count, Mean: rs.mean, Min: rs.min, Max: rs.max, Variance: variance}
+}
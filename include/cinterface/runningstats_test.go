@@ -0,0 +1,42 @@
+package splinter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRunningStats(t *testing.T) {
+	bs := newTestSpline1D(t)
+	rs, err := NewRunningStats(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	empty := rs.Snapshot()
+	if empty.Count != 0 || !math.IsNaN(empty.Min) || !math.IsNaN(empty.Max) {
+		t.Errorf("unexpected empty snapshot %+v", empty)
+	}
+
+	// x² at 0, 1 and 2 gives 0, 1 and 4
+	for _, x := range []float64{0, 1, 2} {
+		if err := rs.Observe(x); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := rs.Observe(1, 2); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+
+	s := rs.Snapshot()
+	if s.Count != 3 || !almostEqual(s.Mean, 5.0/3, 1e-9) || !almostEqual(s.Min, 0, 1e-9) || !almostEqual(s.Max, 4, 1e-9) {
+		t.Errorf("unexpected snapshot %+v", s)
+	}
+	// squared deviations 25/9 + 4/9 + 49/9 = 26/3, over 2
+	if !almostEqual(s.Variance, 13.0/3, 1e-9) {
+		t.Errorf("expected variance %v, got %v", 13.0/3, s.Variance)
+	}
+
+	if _, err := NewRunningStats(nil); err != ErrInvalidNil {
+		t.Errorf("expected ErrInvalidNil, got %v", err)
+	}
+}
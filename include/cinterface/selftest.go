@@ -0,0 +1,83 @@
+package splinter
+
+import (
+	"fmt"
+	"math"
+)
+
+// selfTestPoints is the number of points SelfTestSerialization compares the spline at.
+const selfTestPoints = 256
+
+// SelfTestSerialization saves the spline in splinter's format to memory, loads it back and checks that both evaluate
+// within tol of each other at selfTestPoints Halton points spread over the domain, returning ErrRoundTripMismatch
+// otherwise.
+func (bs *BSpline) SelfTestSerialization(tol float64) error {
+	if tol < 0 || math.IsNaN(tol) {
+		return ErrInvalidTolerance
+	}
+
+	data, err := bs.saveBytes()
+	if err != nil {
+		return err
+	}
+	loaded, err := loadBSplineBytes(data)
+	if err != nil {
+		return err
+	}
+	defer loaded.Free()
+
+	domain, err := bs.GetDomain()
+	if err != nil {
+		return err
+	}
+	points := haltonPoints(selfTestPoints, len(domain))
+	for _, point := range points {
+		for j, u := range point {
+			point[j] = domain[j][0] + u*(domain[j][1]-domain[j][0])
+		}
+	}
+
+	// the saved format does not keep the NonNegative setting, so the raw values are compared
+	flat, err := bs.flattenSamples(points)
+	if err != nil {
+		return err
+	}
+	expected, err := bs.evalRowMajorRaw(flat, len(points))
+	if err != nil {
+		return err
+	}
+	got, err := loaded.evalRowMajorRaw(flat, len(points))
+	if err != nil {
+		return err
+	}
+	for i := range expected {
+		if !(math.Abs(got[i]-expected[i]) <= tol) {
+			return ErrRoundTripMismatch
+		}
+	}
+	return nil
+}
+
+// ErrUnexpectedDimensions is returned by ExpectDimensions when the spline has Found variables instead of the Expected
+// number.
+type ErrUnexpectedDimensions struct {
+	Expected int
+	Found    int
+}
+
+func (e ErrUnexpectedDimensions) Error() string {
+	return fmt.Sprintf("BSpline has %d variables, expected %d", e.Found, e.Expected)
+}
+
+// ExpectDimensions checks that the spline has n variables, returning an ErrUnexpectedDimensions otherwise. It guards
+// code that was written for a particular model against being handed another, say after loading the wrong file.
+func (bs *BSpline) ExpectDimensions(n int) error {
+	found, err := bs.NumVariables()
+	if err != nil {
+		return err
+	}
+	if found != n {
+		return ErrUnexpectedDimensions{Expected: n, Found: found}
+	}
+	return nil
+}
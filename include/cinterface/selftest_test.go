@@ -0,0 +1,32 @@
+package splinter
+
+import (
+	"testing"
+)
+
+func TestSelfTestSerialization(t *testing.T) {
+	for _, bs := range []*BSpline{newTestSpline1D(t), newTestSpline2D(t)} {
+		if err := bs.SelfTestSerialization(0); err != nil {
+			t.Errorf("expected an exact round trip, got %v", err)
+		}
+	}
+
+	if err := newTestSpline1D(t).SelfTestSerialization(-1); err != ErrInvalidTolerance {
+		t.Errorf("expected ErrInvalidTolerance, got %v", err)
+	}
+}
+
+func TestExpectDimensions(t *testing.T) {
+	bs := newTestSpline2D(t)
+	if err := bs.ExpectDimensions(2); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	err := bs.ExpectDimensions(3)
+	if err != (ErrUnexpectedDimensions{Expected: 3, Found: 2}) {
+		t.Errorf("expected ErrUnexpectedDimensions, got %v", err)
+	}
+	if err.Error() != "BSpline has 2 variables, expected 3" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+}
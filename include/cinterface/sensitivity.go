@@ -0,0 +1,229 @@
+package splinter
+
+import (
+	"math"
+	"math/rand"
+)
+
+// sampleBox returns the box inputs are drawn from for sensitivity analysis: the range of the samples in dt per
+// variable, or the spline's domain when dt is nil.
+func (bs *BSpline) sampleBox(dt *DataTable) ([][]float64, error) {
+	domain, err := bs.GetDomain()
+	if err != nil {
+		return nil, err
+	}
+	if dt == nil {
+		return domain, nil
+	}
+
+	if len(dt.y) == 0 {
+		return nil, ErrNoSamples
+	}
+	if dt.numVariables() != len(domain) {
+		return nil, ErrDimensionMismatch
+	}
+
+	box := make([][]float64, len(domain))
+	for i := range box {
+		box[i] = []float64{dt.x[0][i], dt.x[0][i]}
+	}
+	for _, row := range dt.x {
+		for i, v := range row {
+			if v < box[i][0] {
+				box[i][0] = v
+			}
+			if v > box[i][1] {
+				box[i][1] = v
+			}
+		}
+	}
+	return box, nil
+}
+
+// SobolIndices estimates the first-order Sobol index of each input variable: the fraction of the output variance
+// explained by that variable alone. Inputs are drawn independently and uniformly from the range of the samples in dt,
+// or from the spline's domain when dt is nil. The estimate uses Saltelli's Monte Carlo scheme with samples base
+// points, evaluating the spline at samples*(numVariables+2) points in one call; its error shrinks like
+// 1/sqrt(samples). A constant spline has no variance to attribute and gets indices of 0.
+func (bs *BSpline) SobolIndices(dt *DataTable, samples int, rng *rand.Rand) ([]float64, error) {
+	if rng == nil {
+		return nil, ErrInvalidNil
+	}
+	if samples < 2 {
+		return nil, ErrInvalidCount
+	}
+
+	box, err := bs.sampleBox(dt)
+	if err != nil {
+		return nil, err
+	}
+	d := len(box)
+
+	draw := func() []float64 {
+		x := make([]float64, d)
+		for i, b := range box {
+			x[i] = b[0] + rng.Float64()*(b[1]-b[0])
+		}
+		return x
+	}
+
+	// points: the rows of A, then B, then A with column i taken from B for each i
+	points := make([][]float64, samples*(d+2))
+	for k := 0; k < samples; k++ {
+		points[k] = draw()
+		points[samples+k] = draw()
+	}
+	for i := 0; i < d; i++ {
+		for k := 0; k < samples; k++ {
+			x := append([]float64(nil), points[k]...)
+			x[i] = points[samples+k][i]
+			points[(2+i)*samples+k] = x
+		}
+	}
+
+	values, err := bs.evalSamples(points)
+	if err != nil {
+		return nil, err
+	}
+	fA, fB := values[:samples], values[samples:2*samples]
+
+	mean := 0.0
+	for _, v := range values[:2*samples] {
+		mean += v / float64(2*samples)
+	}
+	variance := 0.0
+	for _, v := range values[:2*samples] {
+		variance += (v - mean) * (v - mean) / float64(2*samples-1)
+	}
+
+	indices := make([]float64, d)
+	if variance == 0 {
+		return indices, nil
+	}
+	for i := range indices {
+		fABi := values[(2+i)*samples : (3+i)*samples]
+		sum := 0.0
+		for k := 0; k < samples; k++ {
+			sum += fB[k] * (fABi[k] - fA[k])
+		}
+		indices[i] = sum / float64(samples) / variance
+	}
+	return indices, nil
+}
+
+// LocalSensitivity approximates the gradient of the spline at center by central differences with step delta, returning
+// (f(center + delta*e_i) - f(center - delta*e_i)) / (2*delta) for each variable i. It serves to cross-check
+// EvalJacobian with explicit control of the step. Every perturbed point must lie in the domain of the spline, or
+// ErrOutsideDomain is returned; the 2*numVariables evaluations are done in one call into splinter.
+func (bs *BSpline) LocalSensitivity(center []float64, delta float64) ([]float64, error) {
+	if !(delta > 0) {
+		return nil, ErrInvalidStep
+	}
+
+	domain, err := bs.GetDomain()
+	if err != nil {
+		return nil, err
+	}
+	n := len(domain)
+	if len(center) != n {
+		return nil, ErrDimensionMismatch
+	}
+
+	// points: center + delta*e_i and center - delta*e_i for each i in turn
+	points := make([][]float64, 2*n)
+	for i := 0; i < n; i++ {
+		for k, step := range []float64{delta, -delta} {
+			x := append([]float64(nil), center...)
+			x[i] += step
+			if !insideBounds(x, domain) {
+				return nil, ErrOutsideDomain
+			}
+			points[2*i+k] = x
+		}
+	}
+
+	values, err := bs.evalSamples(points)
+	if err != nil {
+		return nil, err
+	}
+
+	sensitivity := make([]float64, n)
+	for i := range sensitivity {
+		sensitivity[i] = (values[2*i] - values[2*i+1]) / (2 * delta)
+	}
+	return sensitivity, nil
+}
+
+// EvalPerturbations evaluates the spline at base and at base + deltas[i]*e_i for each variable i, in one call into
+// splinter, returning the value at base and the perturbed values in the order of the variables. Deltas may be negative
+// or zero. As with LocalSensitivity, ErrOutsideDomain is returned if base or a perturbed point lies outside
+// the domain.
+func (bs *BSpline) EvalPerturbations(base []float64, deltas []float64) (baseVal float64, perturbed []float64, err error) {
+	domain, err := bs.GetDomain()
+	if err != nil {
+		return 0, nil, err
+	}
+	n := len(domain)
+	if len(base) != n || len(deltas) != n {
+		return 0, nil, ErrDimensionMismatch
+	}
+
+	if !insideBounds(base, domain) {
+		return 0, nil, ErrOutsideDomain
+	}
+
+	points := make([][]float64, n+1)
+	points[0] = base
+	for i, delta := range deltas {
+		x := append([]float64(nil), base...)
+		x[i] += delta
+		if !insideBounds(x, domain) {
+			return 0, nil, ErrOutsideDomain
+		}
+		points[i+1] = x
+	}
+
+	values, err := bs.evalSamples(points)
+	if err != nil {
+		return 0, nil, err
+	}
+	return values[0], values[1:], nil
+}
+
+// AcquisitionScore returns a cheap heuristic for where sampling next would help an active learning loop most. It is
+// d * (1 + |grad f|), with d the Euclidean distance from the point to the nearest sample in dt and |grad f| the norm
+// of the spline's gradient at the point: zero at the samples themselves, growing away from them, and faster where the
+// spline is steep, since errors there cost more. The two factors are in the units of the inputs and of the output per
+// input, so scores are only comparable between points of the same problem; standardizing the inputs (see
+// DataTable.Standardize) keeps one variable from dominating the distance.
+func (bs *BSpline) AcquisitionScore(dt *DataTable, vals ...float64) (float64, error) {
+	if dt == nil {
+		return 0, ErrInvalidNil
+	}
+	n, err := bs.NumVariables()
+	if err != nil {
+		return 0, err
+	}
+	if len(vals) != n {
+		return 0, ErrDimensionMismatch
+	}
+
+	nearest, err := dt.nearestSample(vals)
+	if err != nil {
+		return 0, err
+	}
+	distSq := 0.0
+	for j, v := range dt.x[nearest] {
+		distSq += (v - vals[j]) * (v - vals[j])
+	}
+
+	gradient, err := bs.evalJacobianRowMajor(vals, 1)
+	if err != nil {
+		return 0, err
+	}
+	normSq := 0.0
+	for _, g := range gradient {
+		normSq += g * g
+	}
+	return math.Sqrt(distSq) * (1 + math.Sqrt(normSq)), nil
+}
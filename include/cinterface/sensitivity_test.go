@@ -0,0 +1,109 @@
+package splinter
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSobolIndices(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	// for x0^2 + x0*x1 with uniform inputs on the unit square:
+	// Var f = 0.220833, Var E[f|x0] = 0.193056 and Var E[f|x1] = 1/48
+	indices, err := bs.SobolIndices(nil, 20000, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []float64{0.193056 / 0.220833, (1.0 / 48) / 0.220833}
+	for i, s := range indices {
+		if !almostEqual(s, expected[i], 0.03) {
+			t.Errorf("input %d: expected about %v, got %v", i, expected[i], s)
+		}
+	}
+
+	if _, err := bs.SobolIndices(nil, 1, rand.New(rand.NewSource(1))); err != ErrInvalidCount {
+		t.Errorf("expected ErrInvalidCount, got %v", err)
+	}
+	if _, err := bs.SobolIndices(noisyTable(t), 100, rand.New(rand.NewSource(1))); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
+func TestLocalSensitivity(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	// central differences are exact for the quadratic x0^2 + x0*x1, whose gradient is (2x0 + x1, x0)
+	sensitivity, err := bs.LocalSensitivity([]float64{0.5, 0.25}, 0.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sensitivity) != 2 || !almostEqual(sensitivity[0], 1.25, 1e-9) || !almostEqual(sensitivity[1], 0.5, 1e-9) {
+		t.Errorf("expected [1.25 0.5], got %v", sensitivity)
+	}
+
+	if _, err := bs.LocalSensitivity([]float64{0.95, 0.5}, 0.1); err != ErrOutsideDomain {
+		t.Errorf("expected ErrOutsideDomain, got %v", err)
+	}
+	if _, err := bs.LocalSensitivity([]float64{0.5}, 0.1); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := bs.LocalSensitivity([]float64{0.5, 0.5}, 0); err != ErrInvalidStep {
+		t.Errorf("expected ErrInvalidStep, got %v", err)
+	}
+}
+
+func TestEvalPerturbations(t *testing.T) {
+	bs := newTestSpline2D(t)
+
+	baseVal, perturbed, err := bs.EvalPerturbations([]float64{0.5, 0.25}, []float64{0.25, -0.25})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual(baseVal, 0.375, 1e-9) {
+		t.Errorf("expected 0.375 at the base point, got %v", baseVal)
+	}
+	expected := []float64{bilinearish([]float64{0.75, 0.25}), bilinearish([]float64{0.5, 0})}
+	if len(perturbed) != 2 || !almostEqual(perturbed[0], expected[0], 1e-9) || !almostEqual(perturbed[1], expected[1], 1e-9) {
+		t.Errorf("expected %v, got %v", expected, perturbed)
+	}
+
+	if _, _, err := bs.EvalPerturbations([]float64{0.5, 0.25}, []float64{0.1}); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, _, err := bs.EvalPerturbations([]float64{0.5, 0.25}, []float64{0.6, 0}); err != ErrOutsideDomain {
+		t.Errorf("expected ErrOutsideDomain, got %v", err)
+	}
+}
+
+func TestAcquisitionScore(t *testing.T) {
+	bs := newTestSpline1D(t)
+	dt, err := bs.Resample([][]float64{{0, 1, 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// at a sample the score vanishes
+	if score, err := bs.AcquisitionScore(dt, 1); err != nil || score != 0 {
+		t.Errorf("expected 0 at a sample, got %v, %v", score, err)
+	}
+
+	// 0.5 from the nearest sample, where the slope 2x of x^2 is 1 and 3
+	low, err := bs.AcquisitionScore(dt, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	high, err := bs.AcquisitionScore(dt, 1.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual(low, 0.5*2, 1e-6) || !almostEqual(high, 0.5*4, 1e-6) {
+		t.Errorf("expected 1 and 2, got %v and %v", low, high)
+	}
+
+	if _, err := bs.AcquisitionScore(dt, 1, 2); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := bs.AcquisitionScore(nil, 1); err != ErrInvalidNil {
+		t.Errorf("expected ErrInvalidNil, got %v", err)
+	}
+}
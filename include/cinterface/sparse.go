@@ -0,0 +1,43 @@
+package splinter
+
+// SparseRow is a sample whose inputs are given sparsely, as the values of the variables listed in Indices, all other
+// variables being zero, see NewDataTableSparse.
+type SparseRow struct {
+	Indices []int
+	Values  []float64
+	Y       float64
+}
+
+// NewDataTableSparse creates a table of samples with dim input variables from rows listing only their non-zero
+// inputs. Indices must lie in [0, dim), or ErrInvalidVariable is returned, and must not repeat within a row, or
+// ErrDuplicateIndex is returned. The rows are expanded to dense inputs before they are added, so the table takes as
+// much memory as a dense one; as with AddColumns, a sample whose inputs repeat an earlier one is discarded.
+func NewDataTableSparse(dim int, rows []SparseRow) (*DataTable, error) {
+	if dim < 1 {
+		return nil, ErrInvalidCount
+	}
+
+	x := make([][]float64, len(rows))
+	y := make([]float64, len(rows))
+	for r, row := range rows {
+		if len(row.Indices) != len(row.Values) {
+			return nil, ErrLengthMismatch
+		}
+
+		x[r] = make([]float64, dim)
+		seen := make(map[int]bool, len(row.Indices))
+		for k, i := range row.Indices {
+			if i < 0 || i >= dim {
+				return nil, ErrInvalidVariable
+			}
+			if seen[i] {
+				return nil, ErrDuplicateIndex
+			}
+			seen[i] = true
+			x[r][i] = row.Values[k]
+		}
+		y[r] = row.Y
+	}
+
+	return newDataTableFromSamples(x, y)
+}
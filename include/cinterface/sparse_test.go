@@ -0,0 +1,42 @@
+package splinter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewDataTableSparse(t *testing.T) {
+	dt, err := NewDataTableSparse(3, []SparseRow{
+		{Indices: []int{2, 0}, Values: []float64{5, 1}, Y: 7},
+		{Indices: nil, Values: nil, Y: -1},
+		{Indices: []int{1}, Values: []float64{2}, Y: 3},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	x, y := dt.Samples()
+	expectedX := [][]float64{{0, 0, 0}, {0, 2, 0}, {1, 0, 5}}
+	expectedY := []float64{-1, 3, 7}
+	if !reflect.DeepEqual(x, expectedX) || !reflect.DeepEqual(y, expectedY) {
+		t.Errorf("expected %v %v, got %v %v", expectedX, expectedY, x, y)
+	}
+
+	invalid := []struct {
+		row SparseRow
+		err error
+	}{
+		{SparseRow{Indices: []int{3}, Values: []float64{1}}, ErrInvalidVariable},
+		{SparseRow{Indices: []int{-1}, Values: []float64{1}}, ErrInvalidVariable},
+		{SparseRow{Indices: []int{1, 1}, Values: []float64{1, 2}}, ErrDuplicateIndex},
+		{SparseRow{Indices: []int{1}, Values: []float64{1, 2}}, ErrLengthMismatch},
+	}
+	for _, c := range invalid {
+		if _, err := NewDataTableSparse(3, []SparseRow{c.row}); err != c.err {
+			t.Errorf("%+v: expected %v, got %v", c.row, c.err, err)
+		}
+	}
+	if _, err := NewDataTableSparse(0, nil); err != ErrInvalidCount {
+		t.Errorf("expected ErrInvalidCount, got %v", err)
+	}
+}
@@ -0,0 +1,369 @@
+package splinter
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Residuals returns the differences y - f(x) between the responses in dt and the spline's predictions, one per
+// sample in the order of DataTable.Samples.
+func (bs *BSpline) Residuals(dt *DataTable) ([]float64, error) {
+	if dt == nil {
+		return nil, ErrInvalidNil
+	}
+
+	predictions, err := bs.evalSamples(dt.x)
+	if err != nil {
+		return nil, err
+	}
+
+	residuals := make([]float64, len(predictions))
+	for i, p := range predictions {
+		residuals[i] = dt.y[i] - p
+	}
+	return residuals, nil
+}
+
+// RMSE returns the root mean squared error of the spline's predictions on the samples in dt.
+func (bs *BSpline) RMSE(dt *DataTable) (float64, error) {
+	residuals, err := bs.Residuals(dt)
+	if err != nil {
+		return 0, err
+	}
+	if len(residuals) == 0 {
+		return 0, ErrNoSamples
+	}
+
+	sumSq := 0.0
+	for _, r := range residuals {
+		sumSq += r * r
+	}
+	return math.Sqrt(sumSq / float64(len(residuals))), nil
+}
+
+// OverfitScore returns RMSE(holdout) - RMSE(train). A large positive gap means the spline fits its training data much
+// better than unseen data, which is a sign it has memorized noise.
+func (bs *BSpline) OverfitScore(train, holdout *DataTable) (gap float64, err error) {
+	trainErr, err := bs.RMSE(train)
+	if err != nil {
+		return 0, err
+	}
+	holdoutErr, err := bs.RMSE(holdout)
+	if err != nil {
+		return 0, err
+	}
+	return holdoutErr - trainErr, nil
+}
+
+// OscillationScore measures Runge-like oscillation of the spline between the samples in dt. On each segment between
+// two consecutive samples that differ only in the last variable (every pair of neighbours for a spline with one
+// variable, the grid lines along the last variable for gridded data), the spline is evaluated at subdiv evenly spaced
+// interior points and its overshoot beyond the two responses at the ends of the segment is recorded. The score is the
+// largest overshoot relative to the range of the responses in dt (absolute if they are constant), so 0 means the
+// spline never leaves the local data range and values around 0.1 or more suggest lowering the degree or smoothing.
+func (bs *BSpline) OscillationScore(dt *DataTable, subdiv int) (float64, error) {
+	if dt == nil {
+		return 0, ErrInvalidNil
+	}
+	if subdiv < 1 {
+		return 0, ErrInvalidCount
+	}
+	if len(dt.y) < 2 {
+		return 0, ErrNoSamples
+	}
+
+	n, err := bs.NumVariables()
+	if err != nil {
+		return 0, err
+	}
+	if len(dt.x[0]) != n {
+		return 0, ErrDimensionMismatch
+	}
+
+	// segments start at the samples that have a neighbour along the last variable; samples are sorted, so that
+	// neighbour is the next sample
+	var starts []int
+	for i := 0; i+1 < len(dt.x); i++ {
+		if sameLeading(dt.x[i], dt.x[i+1]) {
+			starts = append(starts, i)
+		}
+	}
+
+	var points [][]float64
+	for _, i := range starts {
+		a, b := dt.x[i], dt.x[i+1]
+		for k := 1; k <= subdiv; k++ {
+			t := float64(k) / float64(subdiv+1)
+			point := make([]float64, n)
+			for j := range point {
+				point[j] = a[j] + t*(b[j]-a[j])
+			}
+			points = append(points, point)
+		}
+	}
+	values, err := bs.evalSamples(points)
+	if err != nil {
+		return 0, err
+	}
+
+	overshoot := 0.0
+	for s, i := range starts {
+		lo, hi := math.Min(dt.y[i], dt.y[i+1]), math.Max(dt.y[i], dt.y[i+1])
+		for _, v := range values[s*subdiv : (s+1)*subdiv] {
+			overshoot = math.Max(overshoot, math.Max(v-hi, lo-v))
+		}
+	}
+
+	lo, hi := dt.y[0], dt.y[0]
+	for _, y := range dt.y {
+		lo, hi = math.Min(lo, y), math.Max(hi, y)
+	}
+	if hi > lo {
+		overshoot /= hi - lo
+	}
+	return overshoot, nil
+}
+
+// sameLeading reports whether a and b agree in all but their last element.
+func sameLeading(a, b []float64) bool {
+	for j := 0; j < len(a)-1; j++ {
+		if a[j] != b[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// OutputHistogram evaluates the spline at the inputs in dt and bins the predictions into bins equally wide bins
+// spanning their range, returning the bins+1 bin edges and the number of predictions in each bin. Bins include their
+// lower edge, and the last one its upper edge too. If all predictions are equal the bins span a unit interval centred
+// on them. To characterize the spline over a grid instead, pass the table returned by Resample.
+func (bs *BSpline) OutputHistogram(dt *DataTable, bins int) (edges, counts []float64, err error) {
+	if dt == nil {
+		return nil, nil, ErrInvalidNil
+	}
+	if bins <= 0 {
+		return nil, nil, ErrInvalidCount
+	}
+	if len(dt.y) == 0 {
+		return nil, nil, ErrNoSamples
+	}
+
+	values, err := bs.evalSamples(dt.x)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	if lo == hi {
+		lo, hi = lo-0.5, hi+0.5
+	}
+
+	edges, counts = histogram(values, lo, hi, bins)
+	return edges, counts, nil
+}
+
+// OutputEntropy returns the entropy in nats, -sum p_i*ln(p_i), of the spline's predictions at the inputs in dt binned
+// like OutputHistogram, with p_i the fraction of predictions in bin i. It ranges from 0, when all predictions fall in
+// one bin, to ln(bins), when they are spread evenly. As the bins span the range of the predictions however narrow it
+// is, the entropy describes their spread relative to that range, not its width. This is the discrete entropy of the
+// binned outputs; adding the logarithm of the bin width approximates the differential entropy of the output
+// distribution.
+func (bs *BSpline) OutputEntropy(dt *DataTable, bins int) (float64, error) {
+	_, counts, err := bs.OutputHistogram(dt, bins)
+	if err != nil {
+		return 0, err
+	}
+
+	total := float64(len(dt.y))
+	entropy := 0.0
+	for _, c := range counts {
+		if c > 0 {
+			p := c / total
+			entropy -= p * math.Log(p)
+		}
+	}
+	return entropy, nil
+}
+
+// histogram bins values lying in [lo, hi] into bins equally wide bins, returning the bins+1 bin edges and the count
+// of each bin. Bins include their lower edge, and the last one its upper edge too.
+func histogram(values []float64, lo, hi float64, bins int) (edges, counts []float64) {
+	edges = linspace(lo, hi, bins+1)
+	counts = make([]float64, bins)
+	for _, v := range values {
+		i := int(float64(bins) * (v - lo) / (hi - lo))
+		if i == bins {
+			i--
+		}
+		counts[i]++
+	}
+	return edges, counts
+}
+
+// KnotDensity bins the interior knots of variable dim into bins equally wide bins spanning its domain, returning the
+// bins+1 bin edges and the number of knots in each bin, to check where the knots are concentrated. The boundary
+// knots, which are repeated degree+1 times by clamping, are left out.
+func (bs *BSpline) KnotDensity(dim, bins int) (edges, counts []float64, err error) {
+	if bins <= 0 {
+		return nil, nil, ErrInvalidCount
+	}
+	knotVectors, err := bs.KnotVectors()
+	if err != nil {
+		return nil, nil, err
+	}
+	if dim < 0 || dim >= len(knotVectors) {
+		return nil, nil, ErrInvalidVariable
+	}
+
+	knots := knotVectors[dim]
+	lo, hi := knots[0], knots[len(knots)-1]
+	interior := make([]float64, 0, len(knots))
+	for _, k := range knots {
+		if k > lo && k < hi {
+			interior = append(interior, k)
+		}
+	}
+
+	edges, counts = histogram(interior, lo, hi, bins)
+	return edges, counts, nil
+}
+
+// IsConstant reports whether the spline is constant to within tol, that is whether its values over a grid of
+// gridPerDim evenly spaced points per variable spanning the domain differ by at most tol, and returns that observed
+// range (largest minus smallest value). A constant fit usually means the builder was misconfigured; when the spline is
+// constant, Eval at any point gives its value.
+func (bs *BSpline) IsConstant(tol float64, gridPerDim int) (constant bool, spread float64, err error) {
+	if !(tol >= 0) {
+		return false, 0, ErrInvalidTolerance
+	}
+
+	flat, size, err := bs.domainGrid(gridPerDim)
+	if err != nil {
+		return false, 0, err
+	}
+	values, err := bs.evalRowMajor(flat, size)
+	if err != nil {
+		return false, 0, err
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	spread = hi - lo
+	return spread <= tol, spread, nil
+}
+
+// evalSamples evaluates the spline at each of the given input rows in a single call into splinter.
+func (bs *BSpline) evalSamples(x [][]float64) ([]float64, error) {
+	flat, err := bs.flattenSamples(x)
+	if err != nil {
+		return nil, err
+	}
+	return bs.evalRowMajor(flat, len(x))
+}
+
+// flattenSamples concatenates input rows into the row-major layout of evalRowMajor, checking that each holds one value
+// per variable.
+func (bs *BSpline) flattenSamples(x [][]float64) ([]float64, error) {
+	n, err := bs.NumVariables()
+	if err != nil {
+		return nil, err
+	}
+
+	flat := make([]float64, 0, len(x)*n)
+	for _, row := range x {
+		if len(row) != n {
+			return nil, ErrDimensionMismatch
+		}
+		flat = append(flat, row...)
+	}
+	return flat, nil
+}
+
+// quantile returns the q-quantile of sorted values, interpolating linearly between order statistics.
+func quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	i := int(math.Floor(pos))
+	if i >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[i] + (pos-float64(i))*(sorted[i+1]-sorted[i])
+}
+
+// BootstrapInterval estimates a prediction interval at the given point by residual bootstrap. The residuals of the
+// spline on dt are resampled with replacement and added back to its predictions, the resulting data is refitted with
+// cfg reps times, and the interval is formed by the empirical (1-level)/2 and (1+level)/2 quantiles of the refitted
+// predictions. A fixed seed is used so results are reproducible. Every repetition is a full build, so this is slow
+// for large tables.
+func (bs *BSpline) BootstrapInterval(dt *DataTable, cfg BuilderConfig, reps int, level float64, vals ...float64) (lo, hi float64, err error) {
+	if dt == nil {
+		return 0, 0, ErrInvalidNil
+	}
+	if reps <= 0 {
+		return 0, 0, ErrInvalidCount
+	}
+	if level <= 0 || level >= 1 {
+		return 0, 0, ErrInvalidLevel
+	}
+	if len(dt.y) == 0 {
+		return 0, 0, ErrNoSamples
+	}
+
+	n, err := bs.NumVariables()
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(vals) != n {
+		return 0, 0, ErrDimensionMismatch
+	}
+
+	fitted, err := bs.evalSamples(dt.x)
+	if err != nil {
+		return 0, 0, err
+	}
+	residuals := make([]float64, len(fitted))
+	for i, f := range fitted {
+		residuals[i] = dt.y[i] - f
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	predictions := make([]float64, reps)
+	y := make([]float64, len(fitted))
+	for r := range predictions {
+		for i, f := range fitted {
+			y[i] = f + residuals[rng.Intn(len(residuals))]
+		}
+
+		predictions[r], err = refitAndEval(dt.x, y, cfg, vals)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	sort.Float64s(predictions)
+	return quantile(predictions, (1-level)/2), quantile(predictions, (1+level)/2), nil
+}
+
+// refitAndEval fits a spline to the given samples and evaluates it at vals, freeing everything it allocated.
+func refitAndEval(x [][]float64, y []float64, cfg BuilderConfig, vals []float64) (float64, error) {
+	dt, err := newDataTableFromSamples(x, y)
+	if err != nil {
+		return 0, err
+	}
+	defer dt.Free()
+
+	bs, err := Fit(dt, cfg)
+	if err != nil {
+		return 0, err
+	}
+	defer bs.Free()
+
+	return bs.Eval(vals...)
+}
@@ -0,0 +1,302 @@
+package splinter
+
+import (
+	"math"
+	"testing"
+)
+
+// noisyTable samples sin on [0, 3] with a deterministic perturbation.
+func noisyTable(t *testing.T) *DataTable {
+	x := linspace(0, 3, 40)
+	y := make([]float64, len(x))
+	for i, v := range x {
+		y[i] = math.Sin(v) + 0.1*math.Sin(37*v)
+	}
+
+	dt, err := NewDataTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dt.AddColumns(x, y); err != nil {
+		t.Fatal(err)
+	}
+	return dt
+}
+
+func TestResiduals(t *testing.T) {
+	bs := newTestSpline1D(t)
+	dt, err := bs.Resample([][]float64{linspace(0, 2, 11)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	residuals, err := bs.Residuals(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(residuals) != 11 {
+		t.Fatalf("expected 11 residuals, got %d", len(residuals))
+	}
+	for _, r := range residuals {
+		if !almostEqual(r, 0, 1e-12) {
+			t.Errorf("expected zero residual, got %v", r)
+		}
+	}
+}
+
+func TestOverfitScore(t *testing.T) {
+	train := noisyTable(t)
+	bs, err := Fit(train, BuilderConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	x := linspace(0.01, 2.99, 25)
+	y := make([]float64, len(x))
+	for i, v := range x {
+		y[i] = math.Sin(v) + 0.1*math.Sin(37*v)
+	}
+	holdout, err := NewDataTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := holdout.AddColumns(x, y); err != nil {
+		t.Fatal(err)
+	}
+
+	trainErr, err := bs.RMSE(train)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual(trainErr, 0, 1e-9) {
+		t.Errorf("expected an interpolating fit to have zero training error, got %v", trainErr)
+	}
+
+	gap, err := bs.OverfitScore(train, holdout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gap <= 0.01 {
+		t.Errorf("expected a positive gap for an interpolating fit of noisy data, got %v", gap)
+	}
+
+	empty, err := NewDataTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bs.OverfitScore(train, empty); err != ErrNoSamples {
+		t.Errorf("expected ErrNoSamples, got %v", err)
+	}
+}
+
+func TestBootstrapInterval(t *testing.T) {
+	dt := noisyTable(t)
+	cfg := BuilderConfig{Smoothing: SmoothingPspline, Alpha: 0.1, KnotSpacing: KnotSpacingEquidistant, NumBasisFunctions: []int{10}}
+
+	bs, err := Fit(dt, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lo, hi, err := bs.BootstrapInterval(dt, cfg, 50, 0.9, 1.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prediction, err := bs.Eval(1.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !(lo < hi) || prediction < lo || prediction > hi {
+		t.Errorf("expected a non-empty interval around %v, got [%v, %v]", prediction, lo, hi)
+	}
+
+	if _, _, err := bs.BootstrapInterval(dt, cfg, 50, 1.5, 1.5); err != ErrInvalidLevel {
+		t.Errorf("expected ErrInvalidLevel, got %v", err)
+	}
+	if _, _, err := bs.BootstrapInterval(dt, cfg, 0, 0.9, 1.5); err != ErrInvalidCount {
+		t.Errorf("expected ErrInvalidCount, got %v", err)
+	}
+}
+
+func TestOscillationScore(t *testing.T) {
+	// a cubic interpolating a step rings on both sides of it
+	x := linspace(0, 2, 21)
+	y := make([]float64, len(x))
+	for i, v := range x {
+		if v >= 1 {
+			y[i] = 1
+		}
+	}
+	step, err := NewDataTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := step.AddColumns(x, y); err != nil {
+		t.Fatal(err)
+	}
+	bs, err := Fit(step, BuilderConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	score, err := bs.OscillationScore(step, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score < 0.01 {
+		t.Errorf("expected the step fit to oscillate, got score %v", score)
+	}
+
+	// x0² + x0*x1 is monotonic in x1 for x0 >= 0, so the grid lines along x1 stay within the data range
+	smooth := newTestSpline2D(t)
+	dt, err := smooth.Resample([][]float64{linspace(0, 1, 5), linspace(0, 1, 5)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	score, err = smooth.OscillationScore(dt, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual(score, 0, 1e-12) {
+		t.Errorf("expected no oscillation, got %v", score)
+	}
+
+	if _, err := bs.OscillationScore(step, 0); err != ErrInvalidCount {
+		t.Errorf("expected ErrInvalidCount, got %v", err)
+	}
+	if _, err := smooth.OscillationScore(step, 10); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
+
+func TestOutputHistogram(t *testing.T) {
+	bs := newTestSpline1D(t)
+	dt, err := bs.Resample([][]float64{linspace(0, 2, 21)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// x² on an even grid over [0, 2] is skewed towards small values
+	edges, counts, err := bs.OutputHistogram(dt, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(edges) != 4 || !almostEqual(edges[0], 0, 1e-12) || !almostEqual(edges[3], 4, 1e-12) {
+		t.Errorf("unexpected edges %v", edges)
+	}
+	// the edges 4/3 and 8/3 fall between x = 1.1 and 1.2, and between 1.6 and 1.7
+	expected := []float64{12, 5, 4}
+	for i := range expected {
+		if counts[i] != expected[i] {
+			t.Errorf("expected counts %v, got %v", expected, counts)
+			break
+		}
+	}
+
+	constant, err := NewDataTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := constant.AddColumns([]float64{1}, []float64{0}); err != nil {
+		t.Fatal(err)
+	}
+	edges, counts, err = bs.OutputHistogram(constant, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual(edges[0], 0.5, 1e-12) || !almostEqual(edges[2], 1.5, 1e-12) || counts[0] != 0 || counts[1] != 1 {
+		t.Errorf("unexpected histogram of a single value: %v %v", edges, counts)
+	}
+
+	if _, _, err := bs.OutputHistogram(dt, 0); err != ErrInvalidCount {
+		t.Errorf("expected ErrInvalidCount, got %v", err)
+	}
+}
+
+func TestIsConstant(t *testing.T) {
+	flat := buildTestSpline(t, [][]float64{linspace(0, 1, 6), linspace(0, 1, 6)}, func([]float64) float64 { return 3 })
+	constant, spread, err := flat.IsConstant(1e-9, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !constant || spread > 1e-9 {
+		t.Errorf("expected a constant fit, got %v with spread %v", constant, spread)
+	}
+
+	// x0^2 + x0*x1 ranges over [0, 2] on the unit square
+	constant, spread, err = newTestSpline2D(t).IsConstant(1e-9, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if constant || !almostEqual(spread, 2, 1e-9) {
+		t.Errorf("expected a spread of 2, got %v with spread %v", constant, spread)
+	}
+
+	if _, _, err := flat.IsConstant(-1, 5); err != ErrInvalidTolerance {
+		t.Errorf("expected ErrInvalidTolerance, got %v", err)
+	}
+	if _, _, err := flat.IsConstant(0, 1); err != ErrInvalidCount {
+		t.Errorf("expected ErrInvalidCount, got %v", err)
+	}
+}
+
+func TestKnotDensity(t *testing.T) {
+	bs := newTestSpline1D(t)
+	knotVectors, err := bs.KnotVectors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	knots := knotVectors[0]
+	degree := 3
+
+	edges, counts, err := bs.KnotDensity(0, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(edges) != 5 || edges[0] != 0 || edges[4] != 2 {
+		t.Errorf("expected 5 edges spanning [0, 2], got %v", edges)
+	}
+	total := 0.0
+	for _, c := range counts {
+		total += c
+	}
+	if int(total) != len(knots)-2*(degree+1) {
+		t.Errorf("expected %d interior knots, got %v in %v", len(knots)-2*(degree+1), total, counts)
+	}
+
+	if _, _, err := bs.KnotDensity(1, 4); err != ErrInvalidVariable {
+		t.Errorf("expected ErrInvalidVariable, got %v", err)
+	}
+	if _, _, err := bs.KnotDensity(0, 0); err != ErrInvalidCount {
+		t.Errorf("expected ErrInvalidCount, got %v", err)
+	}
+}
+
+func TestOutputEntropy(t *testing.T) {
+	// the predictions of a straight line at evenly spaced inputs fill equal bins evenly
+	line := buildTestSpline(t, [][]float64{linspace(0, 1, 11)}, func(x []float64) float64 { return x[0] })
+	dt, err := line.Resample([][]float64{linspace(0.05, 0.95, 40)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entropy, err := line.OutputEntropy(dt, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual(entropy, math.Log(4), 1e-9) {
+		t.Errorf("expected ln 4, got %v", entropy)
+	}
+
+	single, err := line.Resample([][]float64{{0.5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entropy, err := line.OutputEntropy(single, 4); err != nil || entropy != 0 {
+		t.Errorf("expected 0 for a single prediction, got %v, %v", entropy, err)
+	}
+
+	if _, err := line.OutputEntropy(dt, 0); err != ErrInvalidCount {
+		t.Errorf("expected ErrInvalidCount, got %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+package splinter
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+)
+
+// PlotOptions controls the output of WriteSVG. Zero values select the defaults.
+type PlotOptions struct {
+	// Width and Height of the image in pixels, 640x480 by default.
+	Width, Height int
+
+	// Resolution is the number of evaluation points per axis, 200 for curves and 50 for heatmaps by default.
+	Resolution int
+}
+
+// plotMargin is the space in pixels left around the plot area for the axis labels.
+const plotMargin = 50
+
+// colorRamp holds the stops of the heatmap colors, from the lowest to the highest value.
+var colorRamp = [][3]float64{
+	{68, 1, 84},
+	{59, 82, 139},
+	{33, 145, 140},
+	{94, 201, 98},
+	{253, 231, 37},
+}
+
+// rampColor maps t in [0, 1] onto colorRamp.
+func rampColor(t float64) string {
+	t = math.Max(0, math.Min(1, t)) * float64(len(colorRamp)-1)
+	i := int(t)
+	if i == len(colorRamp)-1 {
+		i--
+	}
+	f := t - float64(i)
+
+	var rgb [3]int
+	for c := range rgb {
+		rgb[c] = int(math.Round(colorRamp[i][c] + f*(colorRamp[i+1][c]-colorRamp[i][c])))
+	}
+	return fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2])
+}
+
+// WriteSVG plots the spline over its domain as a standalone SVG image: a curve for splines with one variable and a
+// heatmap for splines with two. The axes are labeled with the domain bounds (and the value range for curves).
+func (bs *BSpline) WriteSVG(w io.Writer, opts PlotOptions) error {
+	domain, err := bs.GetDomain()
+	if err != nil {
+		return err
+	}
+	if len(domain) > 2 {
+		return ErrUnsupportedDim
+	}
+
+	if opts.Width <= 0 {
+		opts.Width = 640
+	}
+	if opts.Height <= 0 {
+		opts.Height = 480
+	}
+	if opts.Resolution <= 1 {
+		opts.Resolution = 200
+		if len(domain) == 2 {
+			opts.Resolution = 50
+		}
+	}
+
+	axes := make([][]float64, len(domain))
+	for i, d := range domain {
+		axes[i] = linspace(d[0], d[1], opts.Resolution)
+	}
+	values, err := bs.EvalGrid(axes)
+	if err != nil {
+		return err
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	if hi == lo {
+		// flat spline, give the value axis some extent
+		lo, hi = lo-0.5, hi+0.5
+	}
+
+	left, top := float64(plotMargin), float64(plotMargin)
+	width := float64(opts.Width - 2*plotMargin)
+	height := float64(opts.Height - 2*plotMargin)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		opts.Width, opts.Height, opts.Width, opts.Height)
+	fmt.Fprintf(&buf, `<rect width="100%%" height="100%%" fill="white"/>`+"\n")
+
+	if len(domain) == 1 {
+		fmt.Fprintf(&buf, `<path fill="none" stroke="#1f77b4" stroke-width="2" d="`)
+		for k, v := range values {
+			px := left + width*float64(k)/float64(len(values)-1)
+			py := top + height*(hi-v)/(hi-lo)
+			cmd := "L"
+			if k == 0 {
+				cmd = "M"
+			}
+			fmt.Fprintf(&buf, "%s%.2f %.2f ", cmd, px, py)
+		}
+		fmt.Fprintf(&buf, `"/>`+"\n")
+		writeSVGLabel(&buf, left-5, top+height, "end", lo)
+		writeSVGLabel(&buf, left-5, top+10, "end", hi)
+	} else {
+		// one cell per grid point; the first variable runs left to right, the second bottom to top
+		n := opts.Resolution
+		cellW, cellH := width/float64(n), height/float64(n)
+		for k, v := range values {
+			i, j := k/n, k%n
+			fmt.Fprintf(&buf, `<rect x="%.2f" y="%.2f" width="%.2f" height="%.2f" fill="%s"/>`+"\n",
+				left+float64(i)*cellW, top+height-float64(j+1)*cellH, cellW+0.5, cellH+0.5, rampColor((v-lo)/(hi-lo)))
+		}
+		writeSVGLabel(&buf, left-5, top+height, "end", domain[1][0])
+		writeSVGLabel(&buf, left-5, top+10, "end", domain[1][1])
+	}
+
+	fmt.Fprintf(&buf, `<rect x="%.2f" y="%.2f" width="%.2f" height="%.2f" fill="none" stroke="black"/>`+"\n",
+		left, top, width, height)
+	writeSVGLabel(&buf, left, top+height+20, "start", domain[0][0])
+	writeSVGLabel(&buf, left+width, top+height+20, "end", domain[0][1])
+	fmt.Fprintf(&buf, "</svg>\n")
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+func writeSVGLabel(buf *bytes.Buffer, x, y float64, anchor string, value float64) {
+	fmt.Fprintf(buf, `<text x="%.2f" y="%.2f" text-anchor="%s" font-family="sans-serif" font-size="12">%.4g</text>`+"\n",
+		x, y, anchor, value)
+}
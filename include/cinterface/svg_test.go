@@ -0,0 +1,38 @@
+package splinter
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWriteSVG(t *testing.T) {
+	for _, bs := range []*BSpline{newTestSpline1D(t), newTestSpline2D(t)} {
+		var buf bytes.Buffer
+		if err := bs.WriteSVG(&buf, PlotOptions{Resolution: 20}); err != nil {
+			t.Fatal(err)
+		}
+
+		// the output must be well-formed XML
+		decoder := xml.NewDecoder(strings.NewReader(buf.String()))
+		for {
+			_, err := decoder.Token()
+			if err != nil {
+				if err != io.EOF {
+					t.Fatalf("invalid SVG: %v", err)
+				}
+				break
+			}
+		}
+		if !strings.HasPrefix(buf.String(), "<svg") {
+			t.Errorf("expected an <svg> root element")
+		}
+	}
+
+	cube := buildTestSpline(t, [][]float64{linspace(0, 1, 4), linspace(0, 1, 4), linspace(0, 1, 4)}, square)
+	if err := cube.WriteSVG(&bytes.Buffer{}, PlotOptions{}); err != ErrUnsupportedDim {
+		t.Errorf("expected ErrUnsupportedDim, got %v", err)
+	}
+}
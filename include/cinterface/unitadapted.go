@@ -0,0 +1,93 @@
+package splinter
+
+// UnitAdaptedBSpline evaluates a spline fitted in one system of units for callers working in another. Inputs are
+// converted to the spline's units as x[i]*inputScale[i] + inputOffset[i] and its output back as
+// f*outputScale + outputOffset.
+type UnitAdaptedBSpline struct {
+	bs           *BSpline
+	inputScale   []float64
+	inputOffset  []float64
+	outputScale  float64
+	outputOffset float64
+}
+
+// NewUnitAdaptedBSpline wraps bs with the given affine unit conversions, one input scale and offset per variable. The
+// spline is shared, not copied.
+func NewUnitAdaptedBSpline(bs *BSpline, inputScale, inputOffset []float64, outputScale, outputOffset float64) (*UnitAdaptedBSpline, error) {
+	if bs == nil {
+		return nil, ErrInvalidNil
+	}
+
+	n, err := bs.NumVariables()
+	if err != nil {
+		return nil, err
+	}
+	if len(inputScale) != n || len(inputOffset) != n {
+		return nil, ErrDimensionMismatch
+	}
+
+	return &UnitAdaptedBSpline{
+		bs:           bs,
+		inputScale:   append([]float64(nil), inputScale...),
+		inputOffset:  append([]float64(nil), inputOffset...),
+		outputScale:  outputScale,
+		outputOffset: outputOffset,
+	}, nil
+}
+
+// convertInputs maps caller inputs to the units of the spline.
+func (ub *UnitAdaptedBSpline) convertInputs(vals []float64) ([]float64, error) {
+	if len(vals) != len(ub.inputScale) {
+		return nil, ErrDimensionMismatch
+	}
+
+	x := make([]float64, len(vals))
+	for i, v := range vals {
+		x[i] = v*ub.inputScale[i] + ub.inputOffset[i]
+	}
+	return x, nil
+}
+
+// Eval evaluates the spline at a point given in the caller's units and returns the value in the caller's units.
+func (ub *UnitAdaptedBSpline) Eval(vals ...float64) (float64, error) {
+	x, err := ub.convertInputs(vals)
+	if err != nil {
+		return 0, err
+	}
+
+	v, err := ub.bs.Eval(x...)
+	if err != nil {
+		return 0, err
+	}
+	return v*ub.outputScale + ub.outputOffset, nil
+}
+
+// EvalJacobian returns the gradient with respect to the caller's inputs, in the caller's units: by the chain rule,
+// the spline's partial derivatives scaled by outputScale*inputScale[i].
+func (ub *UnitAdaptedBSpline) EvalJacobian(vals ...float64) ([]float64, error) {
+	x, err := ub.convertInputs(vals)
+	if err != nil {
+		return nil, err
+	}
+
+	gradient, err := ub.bs.evalJacobianRowMajor(x, 1)
+	if err != nil {
+		return nil, err
+	}
+	for i := range gradient {
+		gradient[i] *= ub.outputScale * ub.inputScale[i]
+	}
+	return gradient, nil
+}
+
+// Spline returns the wrapped spline, which is shared, not copied.
+func (ub *UnitAdaptedBSpline) Spline() *BSpline {
+	return ub.bs
+}
+
+// Conversions returns copies of the unit conversions, see NewUnitAdaptedBSpline.
+func (ub *UnitAdaptedBSpline) Conversions() (inputScale, inputOffset []float64, outputScale, outputOffset float64) {
+	inputScale = append([]float64(nil), ub.inputScale...)
+	inputOffset = append([]float64(nil), ub.inputOffset...)
+	return inputScale, inputOffset, ub.outputScale, ub.outputOffset
+}
@@ -0,0 +1,52 @@
+package splinter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnitAdaptedBSpline(t *testing.T) {
+	// the spline takes meters and returns x^2 in square meters, callers use feet and square feet
+	const footInMeters = 0.3048
+	const squareMeterInSquareFeet = 1 / (footInMeters * footInMeters)
+	ub, err := NewUnitAdaptedBSpline(newTestSpline1D(t), []float64{footInMeters}, []float64{0}, squareMeterInSquareFeet, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a length of x feet has x^2 square feet
+	for _, feet := range []float64{1, 3, 6} {
+		v, err := ub.Eval(feet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !almostEqual(v, feet*feet, 1e-6) {
+			t.Errorf("at %v ft: expected %v, got %v", feet, feet*feet, v)
+		}
+
+		gradient, err := ub.EvalJacobian(feet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !almostEqual(gradient[0], 2*feet, 1e-6) {
+			t.Errorf("at %v ft: expected derivative %v, got %v", feet, 2*feet, gradient[0])
+		}
+	}
+
+	inputScale, inputOffset, outputScale, outputOffset := ub.Conversions()
+	if !reflect.DeepEqual(inputScale, []float64{footInMeters}) || !reflect.DeepEqual(inputOffset, []float64{0}) ||
+		outputScale != squareMeterInSquareFeet || outputOffset != 0 {
+		t.Errorf("unexpected conversions %v, %v, %v, %v", inputScale, inputOffset, outputScale, outputOffset)
+	}
+	inputScale[0] = 1
+	if v, err := ub.Eval(3); err != nil || !almostEqual(v, 9, 1e-6) {
+		t.Errorf("expected the conversions to be copies, got %v, %v", v, err)
+	}
+
+	if _, err := ub.Eval(1, 2); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := NewUnitAdaptedBSpline(newTestSpline2D(t), []float64{1}, []float64{0}, 1, 0); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+}
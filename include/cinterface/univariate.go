@@ -0,0 +1,306 @@
+package splinter
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// monotonicitySamples is the number of points sampled over the domain when checking that a spline is monotonic.
+const monotonicitySamples = 257
+
+// univariateDomain returns the domain of a spline that must have exactly one variable.
+func (bs *BSpline) univariateDomain() (lo, hi float64, err error) {
+	domain, err := bs.GetDomain()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if len(domain) != 1 {
+		return 0, 0, ErrNotUnivariate
+	}
+	return domain[0][0], domain[0][1], nil
+}
+
+// Inverse1D returns the inverse x = f⁻¹(y) of a monotonic spline with one variable. The returned function finds x by
+// bisection on Eval over the domain until the bracket is narrower than tol, and returns ErrOutOfRange for values of y
+// the spline does not attain.
+//
+// Monotonicity is verified on a sampled grid over the domain before the inverse is returned; ErrNotMonotonic is
+// returned if the check fails.
+func (bs *BSpline) Inverse1D(tol float64) (func(y float64) (float64, error), error) {
+	if tol <= 0 {
+		return nil, ErrInvalidTolerance
+	}
+
+	lo, hi, err := bs.univariateDomain()
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := bs.EvalGrid([][]float64{linspace(lo, hi, monotonicitySamples)})
+	if err != nil {
+		return nil, err
+	}
+
+	increasing := values[len(values)-1] > values[0]
+	if values[len(values)-1] == values[0] {
+		return nil, ErrNotMonotonic
+	}
+	for i := 1; i < len(values); i++ {
+		if (increasing && values[i] < values[i-1]) || (!increasing && values[i] > values[i-1]) {
+			return nil, ErrNotMonotonic
+		}
+	}
+
+	yLo, yHi := values[0], values[len(values)-1]
+	if !increasing {
+		yLo, yHi = yHi, yLo
+	}
+
+	return func(y float64) (float64, error) {
+		if y < yLo || y > yHi {
+			return 0, ErrOutOfRange
+		}
+
+		a, b := lo, hi
+		for b-a > tol {
+			mid := a + (b-a)/2
+			v, err := bs.Eval(mid)
+			if err != nil {
+				return 0, err
+			}
+
+			if (v < y) == increasing {
+				a = mid
+			} else {
+				b = mid
+			}
+		}
+		return a + (b-a)/2, nil
+	}, nil
+}
+
+// linspace returns n evenly spaced values over [lo, hi].
+func linspace(lo, hi float64, n int) []float64 {
+	res := make([]float64, n)
+	for i := range res {
+		res[i] = lo + (hi-lo)*float64(i)/float64(n-1)
+	}
+	res[n-1] = hi
+	return res
+}
+
+// antiderivative returns the basis and coefficients of the antiderivative F(x) = ∫ f from the start of the domain to
+// x, for a spline f with one variable. F is itself a spline, one degree higher, whose coefficients are running sums of
+// the coefficients of f weighted by the integrals of the corresponding basis functions.
+func (bs *BSpline) antiderivative() (basis1D, []float64, error) {
+	basis, err := bs.basis()
+	if err != nil {
+		return basis1D{}, nil, err
+	}
+	if len(basis) != 1 {
+		return basis1D{}, nil, ErrNotUnivariate
+	}
+
+	coeffs, err := bs.GetCoefficients()
+	if err != nil {
+		return basis1D{}, nil, err
+	}
+
+	t := basis[0].knots
+	p := basis[0].degree
+
+	knots := make([]float64, 0, len(t)+2)
+	knots = append(knots, t[0])
+	knots = append(knots, t...)
+	knots = append(knots, t[len(t)-1])
+
+	integral := make([]float64, len(coeffs)+1)
+	for i, c := range coeffs {
+		integral[i+1] = integral[i] + c*(t[i+p+1]-t[i])/float64(p+1)
+	}
+
+	return basis1D{knots: knots, degree: p + 1}, integral, nil
+}
+
+// CumulativeIntegral returns the integral of a spline with one variable from the start of its domain to each of xs,
+// computed exactly from the coefficients. Points left of the domain give 0 and points right of it give the integral
+// over the whole domain.
+func (bs *BSpline) CumulativeIntegral(xs []float64) ([]float64, error) {
+	basis, integral, err := bs.antiderivative()
+	if err != nil {
+		return nil, err
+	}
+
+	lo, hi := basis.knots[0], basis.knots[len(basis.knots)-1]
+	res := make([]float64, len(xs))
+	for k, x := range xs {
+		if x <= lo {
+			continue
+		}
+		if x >= hi {
+			res[k] = integral[len(integral)-1]
+			continue
+		}
+
+		first, values, _ := basis.eval(x)
+		for i, v := range values {
+			res[k] += integral[first+i] * v
+		}
+	}
+	return res, nil
+}
+
+// densitySamples is the number of points the CDF is tabulated at when sampling from a density spline.
+const densitySamples = 1025
+
+// Sample draws n samples from the distribution whose (unnormalized) density is a spline with one variable. The CDF is
+// tabulated with CumulativeIntegral on a regular grid over the domain, normalized, and inverted by linear
+// interpolation to transform uniform draws from rng.
+//
+// The spline must be non-negative over its domain (up to rounding), otherwise ErrNegativeDensity is returned. As the CDF
+// is integrated from the coefficients, this applies to the spline without the clamp of BSplineBuilder.NonNegative.
+func (bs *BSpline) Sample(n int, rng *rand.Rand) ([]float64, error) {
+	if rng == nil {
+		return nil, ErrInvalidNil
+	}
+	if n < 0 {
+		return nil, ErrInvalidCount
+	}
+
+	lo, hi, err := bs.univariateDomain()
+	if err != nil {
+		return nil, err
+	}
+
+	xs := linspace(lo, hi, densitySamples)
+	values, err := bs.evalRowMajorRaw(xs, len(xs))
+	if err != nil {
+		return nil, err
+	}
+
+	maxAbs := 0.0
+	for _, v := range values {
+		maxAbs = math.Max(maxAbs, math.Abs(v))
+	}
+	for _, v := range values {
+		if v < -1e-9*maxAbs {
+			return nil, ErrNegativeDensity
+		}
+	}
+
+	cdf, err := bs.CumulativeIntegral(xs)
+	if err != nil {
+		return nil, err
+	}
+
+	total := cdf[len(cdf)-1]
+	if total <= 0 {
+		return nil, ErrNegativeDensity
+	}
+
+	res := make([]float64, n)
+	for k := range res {
+		u := rng.Float64() * total
+
+		// first grid point where the CDF reaches u, then interpolate within the preceding cell
+		i := sort.SearchFloat64s(cdf, u)
+		if i == 0 {
+			res[k] = xs[0]
+			continue
+		}
+		if i == len(cdf) {
+			i = len(cdf) - 1
+		}
+
+		width := cdf[i] - cdf[i-1]
+		if width <= 0 {
+			res[k] = xs[i]
+			continue
+		}
+		res[k] = xs[i-1] + (xs[i]-xs[i-1])*(u-cdf[i-1])/width
+	}
+	return res, nil
+}
+
+// EvalDerivs1D evaluates a spline with one variable and its derivatives at x in one call, returning
+// [f(x), f'(x), ..., f⁽ᵐᵃˣᴼʳᵈᵉʳ⁾(x)]. Derivatives are computed on the Go side from the basis, so the whole result costs
+// a single pass over the knot span of x; orders above the degree are zero. At interior knots, where derivatives of
+// order degree and up are discontinuous, the value from the right is returned (from the left at the end of the
+// domain). Points outside the domain give ErrOutsideDomain.
+func (bs *BSpline) EvalDerivs1D(x float64, maxOrder int) ([]float64, error) {
+	if maxOrder < 0 {
+		return nil, ErrInvalidCount
+	}
+
+	basis, err := bs.basis()
+	if err != nil {
+		return nil, err
+	}
+	if len(basis) != 1 {
+		return nil, ErrNotUnivariate
+	}
+	b := basis[0]
+	if !b.insideSupport(x) {
+		return nil, ErrOutsideDomain
+	}
+
+	coeffs, err := bs.GetCoefficients()
+	if err != nil {
+		return nil, err
+	}
+
+	i := b.span(x)
+	derivs := b.evalSpanDerivs(x, i, maxOrder)
+	res := make([]float64, maxOrder+1)
+	for k, values := range derivs {
+		for j, v := range values {
+			res[k] += coeffs[i-b.degree+j] * v
+		}
+	}
+	return res, nil
+}
+
+// TotalVariation1D returns the total variation of a spline with one variable over its domain, the integral of |f'|.
+// The curve is sampled at subdiv evenly spaced steps within each knot span and the absolute differences of consecutive
+// values are summed. This is a lower bound, exact wherever f is monotonic between consecutive samples; as f is a
+// polynomial of low degree on each span, a few steps per span suffice in practice.
+func (bs *BSpline) TotalVariation1D(subdiv int) (float64, error) {
+	if subdiv <= 0 {
+		return 0, ErrInvalidCount
+	}
+
+	basis, err := bs.basis()
+	if err != nil {
+		return 0, err
+	}
+	if len(basis) != 1 {
+		return 0, ErrNotUnivariate
+	}
+
+	knots := basis[0].knots
+	points := [][]float64{{knots[0]}}
+	for i := 0; i+1 < len(knots); i++ {
+		if knots[i] == knots[i+1] {
+			continue
+		}
+		for k := 1; k <= subdiv; k++ {
+			x := knots[i] + (knots[i+1]-knots[i])*float64(k)/float64(subdiv)
+			if k == subdiv {
+				x = knots[i+1]
+			}
+			points = append(points, []float64{x})
+		}
+	}
+
+	values, err := bs.evalSamples(points)
+	if err != nil {
+		return 0, err
+	}
+	tv := 0.0
+	for i := 1; i < len(values); i++ {
+		tv += math.Abs(values[i] - values[i-1])
+	}
+	return tv, nil
+}
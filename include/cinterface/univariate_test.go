@@ -0,0 +1,165 @@
+package splinter
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestInverse1D(t *testing.T) {
+	bs := newTestSpline1D(t)
+
+	inverse, err := bs.Inverse1D(1e-10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, y := range []float64{0.25, 2, 4} {
+		x, err := inverse(y)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !almostEqual(x, math.Sqrt(y), 1e-8) {
+			t.Errorf("f⁻¹(%v): expected %v, got %v", y, math.Sqrt(y), x)
+		}
+	}
+
+	if _, err := inverse(5); err != ErrOutOfRange {
+		t.Errorf("expected ErrOutOfRange, got %v", err)
+	}
+}
+
+func TestInverse1DNotMonotonic(t *testing.T) {
+	bs := buildTestSpline(t, [][]float64{linspace(-1, 1, 21)}, square)
+	if _, err := bs.Inverse1D(1e-6); err != ErrNotMonotonic {
+		t.Errorf("expected ErrNotMonotonic, got %v", err)
+	}
+
+	if _, err := newTestSpline2D(t).Inverse1D(1e-6); err != ErrNotUnivariate {
+		t.Errorf("expected ErrNotUnivariate, got %v", err)
+	}
+}
+
+func TestCumulativeIntegral(t *testing.T) {
+	bs := newTestSpline1D(t)
+
+	xs := []float64{-1, 0, 0.5, 1.3, 2, 3}
+	integrals, err := bs.CumulativeIntegral(xs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range xs {
+		x = math.Max(0, math.Min(2, x))
+		if !almostEqual(integrals[i], x*x*x/3, 1e-9) {
+			t.Errorf("at %v: expected %v, got %v", xs[i], x*x*x/3, integrals[i])
+		}
+	}
+}
+
+func TestSample(t *testing.T) {
+	bs := newTestSpline1D(t)
+
+	samples, err := bs.Sample(20000, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the density 3x²/8 on [0, 2] has mean 1.5
+	mean := 0.0
+	for _, s := range samples {
+		if s < 0 || s > 2 {
+			t.Fatalf("sample %v outside the domain", s)
+		}
+		mean += s / float64(len(samples))
+	}
+	if !almostEqual(mean, 1.5, 0.02) {
+		t.Errorf("expected mean 1.5, got %v", mean)
+	}
+
+	negative := buildTestSpline(t, [][]float64{linspace(0, 2, 21)}, func(x []float64) float64 { return x[0] - 1 })
+	if _, err := negative.Sample(1, rand.New(rand.NewSource(1))); err != ErrNegativeDensity {
+		t.Errorf("expected ErrNegativeDensity, got %v", err)
+	}
+	// the clamp does not change the integral the samples are drawn from
+	negative.SetNonNegative(true)
+	if _, err := negative.Sample(1, rand.New(rand.NewSource(1))); err != ErrNegativeDensity {
+		t.Errorf("expected ErrNegativeDensity for a clamped spline, got %v", err)
+	}
+}
+
+func TestEvalDerivs1D(t *testing.T) {
+	bs := newTestSpline1D(t)
+
+	for _, x := range []float64{0, 0.35, 1, 1.7, 2} {
+		derivs, err := bs.EvalDerivs1D(x, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []float64{x * x, 2 * x, 2, 0, 0}
+		for k := range expected {
+			if !almostEqual(derivs[k], expected[k], 1e-8) {
+				t.Errorf("x=%v: expected %v, got %v", x, expected, derivs)
+				break
+			}
+		}
+
+		value, err := bs.Eval(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !almostEqual(derivs[0], value, 1e-12) {
+			t.Errorf("x=%v: value %v differs from Eval %v", x, derivs[0], value)
+		}
+	}
+
+	// a cubic on its own: the third derivative is the only constant one
+	cubic := buildTestSpline(t, [][]float64{linspace(-1, 1, 9)}, func(x []float64) float64 { return x[0] * x[0] * x[0] })
+	derivs, err := cubic.EvalDerivs1D(0.3, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, expected := range []float64{0.027, 0.27, 1.8, 6} {
+		if !almostEqual(derivs[k], expected, 1e-8) {
+			t.Errorf("order %d: expected %v, got %v", k, expected, derivs[k])
+		}
+	}
+
+	if _, err := bs.EvalDerivs1D(3, 2); err != ErrOutsideDomain {
+		t.Errorf("expected ErrOutsideDomain, got %v", err)
+	}
+	if _, err := bs.EvalDerivs1D(1, -1); err != ErrInvalidCount {
+		t.Errorf("expected ErrInvalidCount, got %v", err)
+	}
+	if _, err := newTestSpline2D(t).EvalDerivs1D(0.5, 2); err != ErrNotUnivariate {
+		t.Errorf("expected ErrNotUnivariate, got %v", err)
+	}
+}
+
+func TestTotalVariation1D(t *testing.T) {
+	// x² is monotonic on [0, 2], so its variation is f(2) - f(0)
+	tv, err := newTestSpline1D(t).TotalVariation1D(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual(tv, 4, 1e-9) {
+		t.Errorf("expected 4, got %v", tv)
+	}
+
+	// sin on [0, 3π] goes up, down, up and down again: 1 + 2 + 2 + 1
+	wave := buildTestSpline(t, [][]float64{linspace(0, 3*math.Pi, 121)}, func(x []float64) float64 { return math.Sin(x[0]) })
+	tv, err = wave.TotalVariation1D(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual(tv, 6, 1e-4) {
+		t.Errorf("expected 6, got %v", tv)
+	}
+
+	if _, err := wave.TotalVariation1D(0); err != ErrInvalidCount {
+		t.Errorf("expected ErrInvalidCount, got %v", err)
+	}
+	if _, err := newTestSpline2D(t).TotalVariation1D(4); err != ErrNotUnivariate {
+		t.Errorf("expected ErrNotUnivariate, got %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package splinter
+
+// Update returns a spline fitted to the samples the spline was built from together with the samples in dt, using the
+// same builder settings. splinter has no incremental fitting, so this is a full refit of the combined data; the
+// original samples are retained by Build for this purpose. Where a new sample has the same input as an old one, the
+// new response is used.
+//
+// Only splines returned by a builder can be updated. Splines fitted with sample weights cannot, since the weights
+// refer to the original samples only.
+func (bs *BSpline) Update(dt *DataTable) (*BSpline, error) {
+	if dt == nil {
+		return nil, ErrInvalidNil
+	}
+	if bs.config == nil {
+		return nil, ErrNoTrainingData
+	}
+	if bs.weights != nil {
+		return nil, ErrWeightedUpdate
+	}
+
+	n, err := bs.NumVariables()
+	if err != nil {
+		return nil, err
+	}
+	if len(dt.y) > 0 && dt.numVariables() != n {
+		return nil, ErrDimensionMismatch
+	}
+
+	// new samples first, splinter keeps the first of several samples with the same input
+	x := make([][]float64, 0, len(dt.y)+len(bs.y))
+	x = append(append(x, dt.x...), bs.x...)
+	y := make([]float64, 0, len(dt.y)+len(bs.y))
+	y = append(append(y, dt.y...), bs.y...)
+
+	combined, err := newDataTableFromSamples(x, y)
+	if err != nil {
+		return nil, err
+	}
+	defer combined.Free()
+
+	return Fit(combined, *bs.config)
+}
@@ -0,0 +1,62 @@
+package splinter
+
+import (
+	"testing"
+)
+
+func TestUpdate(t *testing.T) {
+	bs := newTestSpline1D(t)
+
+	// extend y = x^2 from [0, 2] to [0, 3], replacing the sample at 2
+	x := linspace(2, 3, 11)
+	y := make([]float64, len(x))
+	for i, v := range x {
+		y[i] = v * v
+	}
+	y[0] = 5
+	dt, err := NewDataTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dt.AddColumns(x, y); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := bs.Update(dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	domain, err := updated.GetDomain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if domain[0][0] != 0 || domain[0][1] != 3 {
+		t.Errorf("expected domain [0, 3], got %v", domain[0])
+	}
+
+	for _, v := range []float64{0.5, 2.5} {
+		got, err := updated.Eval(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !almostEqual(got, v*v, 0.1) {
+			t.Errorf("at %v: expected %v, got %v", v, v*v, got)
+		}
+	}
+	if got, err := updated.Eval(2); err != nil || !almostEqual(got, 5, 1e-9) {
+		t.Errorf("expected the new sample at 2 to win, got %v (%v)", got, err)
+	}
+
+	if _, err := newTestSpline2D(t).Update(dt); err != ErrDimensionMismatch {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+
+	clone, err := bs.clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := clone.Update(dt); err != ErrNoTrainingData {
+		t.Errorf("expected ErrNoTrainingData, got %v", err)
+	}
+}
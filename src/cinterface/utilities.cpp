@@ -7,6 +7,7 @@
  * file, You can obtain one at http://mozilla.org/MPL/2.0/.
 */
 
+#include <string>
 #include <bspline.h>
 #include "cinterface/utilities.h"
 
@@ -24,9 +25,13 @@ int splinter_last_func_call_error = 0;
 
 const char *splinter_error_string = "No error.";
 
+// Copy of the last error message, as the strings passed to set_error_string (exception messages) do not outlive the call
+static std::string splinter_error_message;
+
 void set_error_string(const char *new_error_string)
 {
-    splinter_error_string = new_error_string;
+    splinter_error_message = new_error_string;
+    splinter_error_string = splinter_error_message.c_str();
     splinter_last_func_call_error = 1;
 }
 